@@ -0,0 +1,299 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// createTableRegex 匹配到建表语句的左括号为止，不跨过分号，表名与括号之间可以没有空白；
+// 允许 snowflake 等的 OR REPLACE、TEMPORARY、TRANSIENT 修饰
+var createTableRegex = regexp.MustCompile(`(?i)CREATE\s+(?:OR\s+REPLACE\s+)?(?:(?:GLOBAL\s+|LOCAL\s+)?(?:TEMPORARY|TEMP|TRANSIENT)\s+)?TABLE[^(;]*\(`)
+
+var (
+	// partitionCommentRegex 匹配 SHOW CREATE TABLE 输出的 /*!50100 PARTITION BY ... */ 版本注释
+	partitionCommentRegex = regexp.MustCompile(`(?is)/\*!\d*\s*PARTITION\b.*?\*/`)
+	partitionByRegex      = regexp.MustCompile(`(?i)\bPARTITION\s+BY\b`)
+	alterTableRegex       = regexp.MustCompile("(?is)^\\s*(?:(?:--[^\\n]*\\n|#[^\\n]*\\n|/\\*.*?\\*/)\\s*)*ALTER\\s+TABLE\\s+(?:[`\"]?\\w+[`\"]?\\.)?[`\"]?(\\w+)[`\"]?")
+	alterAddRegex         = regexp.MustCompile(`(?i)\bADD\b`)
+	alterAddColumnRegex   = regexp.MustCompile(`(?i)^ADD\s+(?:COLUMN\s+)?(?:IF\s+NOT\s+EXISTS\s+)?`)
+	// commentOnStatementRegex 匹配 postgres 的 COMMENT ON TABLE/COLUMN 语句，分组为表名
+	commentOnStatementRegex = regexp.MustCompile(`(?is)^\s*(?:(?:--[^\n]*\n|#[^\n]*\n|/\*.*?\*/)\s*)*COMMENT\s+ON\s+(?:TABLE\s+(?:"?\w+"?\.)?"?(\w+)"?|COLUMN\s+(?:"?\w+"?\.)?"?(\w+)"?\."?\w+"?)`)
+	// commentOnRegex 提取 COMMENT ON 语句的对象类型、表名或列名以及注释
+	commentOnRegex = regexp.MustCompile(`(?is)\bCOMMENT\s+ON\s+(TABLE|COLUMN)\s+(?:"?\w+"?\.)*"?(\w+)"?\s+IS\s+'((?:[^']|'')*)'\s*;?`)
+	// dmlStatementRegex 匹配以 INSERT/UPDATE/DELETE/REPLACE 开头（允许前置注释）的语句
+	dmlStatementRegex = regexp.MustCompile(`(?is)^\s*(?:(?:--[^\n]*\n|#[^\n]*\n|/\*.*?\*/)\s*)*(?:INSERT|UPDATE|DELETE|REPLACE)\b`)
+)
+
+// constraintKeywords 表定义中非列定义开头的关键字
+var constraintKeywords = map[string]bool{
+	"PRIMARY":    true,
+	"UNIQUE":     true,
+	"KEY":        true,
+	"INDEX":      true,
+	"CONSTRAINT": true,
+	"FOREIGN":    true,
+	"CHECK":      true,
+	"FULLTEXT":   true,
+	"SPATIAL":    true,
+}
+
+// createTableBody 返回第一个 CREATE TABLE 括号内的定义部分，不含外层括号
+func createTableBody(sqlContent string) string {
+	loc := createTableRegex.FindStringIndex(sqlContent)
+	if loc == nil {
+		return ""
+	}
+	return sqlContent[loc[1]:closingParen(sqlContent, loc[1])]
+}
+
+// closingParen 返回与 start 之前的左括号配对的右括号位置，未闭合时返回内容长度
+func closingParen(sqlContent string, start int) int {
+	depth := 1
+	var quote byte
+	for i := start; i < len(sqlContent); i++ {
+		c := sqlContent[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(sqlContent)
+}
+
+// splitCreateTables 返回SQL中每个 CREATE TABLE 语句，包含列定义之后的表选项
+func splitCreateTables(sqlContent string) []string {
+	var statements []string
+	for {
+		loc := createTableRegex.FindStringIndex(sqlContent)
+		if loc == nil {
+			return statements
+		}
+		end := closingParen(sqlContent, loc[1])
+		end += statementEnd(sqlContent[end:])
+		statements = append(statements, sqlContent[loc[0]:end])
+		sqlContent = sqlContent[end:]
+	}
+}
+
+// tableStatements 按表名（小写）收集 ALTER TABLE 和 COMMENT ON 语句
+func tableStatements(sqlContent string) map[string][]string {
+	alters := make(map[string][]string)
+	for sqlContent != "" {
+		end := statementEnd(sqlContent)
+		statement := sqlContent[:end]
+		name := ""
+		if m := alterTableRegex.FindStringSubmatch(statement); m != nil {
+			name = m[1]
+		} else if m := commentOnStatementRegex.FindStringSubmatch(statement); m != nil {
+			name = m[1] + m[2]
+		}
+		if name != "" {
+			name = strings.ToLower(name)
+			alters[name] = append(alters[name], strings.TrimSpace(statement)+";")
+		}
+		if end < len(sqlContent) {
+			end++
+		}
+		sqlContent = sqlContent[end:]
+	}
+	return alters
+}
+
+// columnDefinitionLines 把建表语句和 ALTER TABLE ... ADD [COLUMN] 中的定义整理为每行一个，
+// 供字段正则逐行匹配
+func columnDefinitionLines(sqlContent string) string {
+	sqlContent = stripSQLComments(sqlContent)
+	definitions := splitDefinitions(createTableBody(sqlContent))
+	for sqlContent != "" {
+		end := statementEnd(sqlContent)
+		statement := sqlContent[:end]
+		if alterTableRegex.MatchString(statement) {
+			if loc := alterAddRegex.FindStringIndex(statement); loc != nil {
+				for _, clause := range splitDefinitions(statement[loc[0]:]) {
+					if m := alterAddColumnRegex.FindStringIndex(clause); m != nil {
+						definitions = append(definitions, clause[m[1]:])
+					}
+				}
+			}
+		}
+		if end < len(sqlContent) {
+			end++
+		}
+		sqlContent = sqlContent[end:]
+	}
+	newlines := strings.NewReplacer("\r", " ", "\n", " ")
+	for i, definition := range definitions {
+		definitions[i] = newlines.Replace(definition)
+	}
+	return strings.Join(definitions, "\n")
+}
+
+// stripSQLComments 去掉引号外的 --、# 行注释和 /* */ 块注释，避免注释中的逗号和引号影响定义拆分
+func stripSQLComments(sqlContent string) string {
+	var builder strings.Builder
+	var quote byte
+	for i := 0; i < len(sqlContent); i++ {
+		c := sqlContent[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && i+1 < len(sqlContent) {
+				builder.WriteByte(c)
+				i++
+				c = sqlContent[i]
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '#' || c == '-' && strings.HasPrefix(sqlContent[i:], "--"):
+			end := strings.IndexByte(sqlContent[i:], '\n')
+			if end < 0 {
+				return builder.String()
+			}
+			i += end
+			c = '\n'
+		case c == '/' && strings.HasPrefix(sqlContent[i:], "/*"):
+			end := strings.Index(sqlContent[i+2:], "*/")
+			if end < 0 {
+				return builder.String()
+			}
+			i += end + 3
+			c = ' '
+		}
+		builder.WriteByte(c)
+	}
+	return builder.String()
+}
+
+// extractCommentOn 返回去掉 COMMENT ON 语句后的SQL，以及其中的表注释和按小写列名索引的列注释
+func extractCommentOn(sqlContent string) (string, string, map[string]string) {
+	tableComment := ""
+	columnComments := make(map[string]string)
+	for _, m := range commentOnRegex.FindAllStringSubmatch(sqlContent, -1) {
+		comment := strings.ReplaceAll(m[3], "''", "'")
+		if strings.EqualFold(m[1], "TABLE") {
+			tableComment = comment
+		} else {
+			columnComments[strings.ToLower(m[2])] = comment
+		}
+	}
+	return commentOnRegex.ReplaceAllString(sqlContent, ""), tableComment, columnComments
+}
+
+// stripDMLStatements 去掉与表结构混在一起的 INSERT 等种子数据语句，
+// 避免其中反引号包裹的列名被当成列定义
+func stripDMLStatements(sqlContent string) string {
+	var builder strings.Builder
+	for sqlContent != "" {
+		end := statementEnd(sqlContent)
+		if end < len(sqlContent) {
+			end++
+		}
+		if !dmlStatementRegex.MatchString(sqlContent[:end]) {
+			builder.WriteString(sqlContent[:end])
+		}
+		sqlContent = sqlContent[end:]
+	}
+	return builder.String()
+}
+
+// stripPartitionClauses 去掉 CREATE TABLE 列定义之后的 PARTITION BY 子句，
+// 避免分区定义中的括号和 COMMENT 被当成列解析
+func stripPartitionClauses(sqlContent string) string {
+	sqlContent = partitionCommentRegex.ReplaceAllString(sqlContent, "")
+	var builder strings.Builder
+	for {
+		loc := createTableRegex.FindStringIndex(sqlContent)
+		if loc == nil {
+			break
+		}
+		end := closingParen(sqlContent, loc[1])
+		builder.WriteString(sqlContent[:end])
+		sqlContent = sqlContent[end:]
+
+		stmtEnd := statementEnd(sqlContent)
+		if m := partitionByRegex.FindStringIndex(sqlContent[:stmtEnd]); m != nil {
+			builder.WriteString(strings.TrimRight(sqlContent[:m[0]], " \t\r\n"))
+			sqlContent = sqlContent[stmtEnd:]
+		}
+	}
+	builder.WriteString(sqlContent)
+	return builder.String()
+}
+
+// statementEnd 返回当前语句结束的分号位置，忽略括号和引号内的分号
+func statementEnd(sqlContent string) int {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(sqlContent); i++ {
+		c := sqlContent[i]
+		switch {
+		case quote != 0:
+			// 种子数据中常见 'it\'s' 这类反斜杠转义
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ';' && depth <= 0:
+			return i
+		}
+	}
+	return len(sqlContent)
+}
+
+// splitDefinitions 按顶层逗号拆分表定义，忽略括号和引号内的逗号
+func splitDefinitions(body string) []string {
+	var definitions []string
+	depth := 0
+	var quote byte
+	last := 0
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			definitions = append(definitions, strings.TrimSpace(body[last:i]))
+			last = i + 1
+		}
+	}
+	if rest := strings.TrimSpace(body[last:]); rest != "" {
+		definitions = append(definitions, rest)
+	}
+	return definitions
+}
+
+// columnDefinitionName 返回列定义的列名，约束定义返回空串
+func columnDefinitionName(definition string) string {
+	fields := strings.Fields(definition)
+	if len(fields) == 0 || constraintKeywords[strings.ToUpper(fields[0])] {
+		return ""
+	}
+	return strings.Trim(fields[0], "`\"")
+}
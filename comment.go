@@ -0,0 +1,50 @@
+package main
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// writeFieldLine 写入带行尾注释的字段行，没有注释时不写注释；设置 --comment-max-width 且整行超宽时，
+// 注释按宽度折行写在字段上方
+func (p *SQLParser) writeFieldLine(builder *strings.Builder, line, comment string) {
+	if comment == "" {
+		builder.WriteString(strings.TrimRight(line, " ") + "\n")
+		return
+	}
+	full := line + " // " + comment
+	if p.CommentMaxWidth == 0 || utf8.RuneCountInString(full) <= p.CommentMaxWidth {
+		builder.WriteString(full + "\n")
+		return
+	}
+	for _, part := range wrapComment(comment, p.CommentMaxWidth-len("\t// ")) {
+		builder.WriteString("\t// " + part + "\n")
+	}
+	builder.WriteString(strings.TrimRight(line, " ") + "\n")
+}
+
+// wrapComment 按字符数折行，优先在空白处断开，没有空白的长串（如中文）按宽度截断
+func wrapComment(text string, width int) []string {
+	width = maxInt(width, 1)
+	var lines []string
+	var current []rune
+	for _, word := range strings.Fields(text) {
+		runes := []rune(word)
+		if len(current) > 0 && len(current)+1+len(runes) > width {
+			lines = append(lines, string(current))
+			current = nil
+		}
+		if len(current) > 0 {
+			current = append(current, ' ')
+		}
+		current = append(current, runes...)
+		for len(current) > width {
+			lines = append(lines, string(current[:width]))
+			current = current[width:]
+		}
+	}
+	if len(current) > 0 {
+		lines = append(lines, string(current))
+	}
+	return lines
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// writeDeepCopy 生成 DeepCopy 方法，切片和指针字段复制底层数据，避免与原对象共享内存
+func (p *SQLParser) writeDeepCopy(builder *strings.Builder) {
+	builder.WriteString("// DeepCopy 返回深拷贝，切片与指针字段不与原对象共享内存\n")
+	builder.WriteString(fmt.Sprintf("func (p *%s) DeepCopy() *%s {\n", p.StructName, p.StructName))
+	builder.WriteString("\tif p == nil {\n\t\treturn nil\n\t}\n")
+	builder.WriteString("\tcp := *p\n")
+	for _, field := range p.Fields {
+		if field.Ignored {
+			continue
+		}
+		if copyCode := deepCopyField(field); copyCode != "" {
+			builder.WriteString(fmt.Sprintf("\tif p.%s != nil {\n%s\t}\n", field.FieldName, copyCode))
+		}
+	}
+	builder.WriteString("\treturn &cp\n}\n\n")
+}
+
+// deepCopyField 返回复制引用类型字段的语句，值类型字段返回空串
+func deepCopyField(field FieldMeta) string {
+	name := field.FieldName
+	switch {
+	case field.FieldType == "*big.Int" || field.FieldType == "*big.Rat":
+		// big 包的类型内部含有切片，按值复制仍会共享底层数组
+		return fmt.Sprintf("\t\tcp.%s = new(%s).Set(p.%s)\n", name, field.FieldType[1:], name)
+	case field.FieldType == "pq.ByteaArray":
+		return fmt.Sprintf("\t\tcp.%s = make(pq.ByteaArray, len(p.%s))\n\t\tfor i, b := range p.%s {\n\t\t\tcp.%s[i] = append([]byte(nil), b...)\n\t\t}\n",
+			name, name, name, name)
+	case strings.HasPrefix(field.FieldType, "[]") || field.FieldType == "json.RawMessage" || isPQArray(field.FieldType):
+		return fmt.Sprintf("\t\tcp.%s = append(%s(nil), p.%s...)\n", name, wrapSliceType(field.FieldType), name)
+	case strings.HasPrefix(field.FieldType, "*"):
+		return fmt.Sprintf("\t\tv := *p.%s\n\t\tcp.%s = &v\n", name, name)
+	}
+	return ""
+}
+
+// isPQArray 判断是否为 pqArrayTypes 中的 lib/pq 数组类型，它们都是切片
+func isPQArray(fieldType string) bool {
+	for _, pqType := range pqArrayTypes {
+		if fieldType == pqType {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapSliceType 切片类型字面量做类型转换时需要加括号，如 ([]byte)(nil)
+func wrapSliceType(fieldType string) string {
+	if strings.HasPrefix(fieldType, "[]") {
+		return "(" + fieldType + ")"
+	}
+	return fieldType
+}
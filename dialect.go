@@ -0,0 +1,729 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Dialect 描述不同数据库方言之间的差异
+type Dialect struct {
+	Name string
+	// TypeMappings/NullableTypeMappings 方言的类型映射，为 nil 时沿用 NewSQLParser 的 MySQL 默认映射
+	TypeMappings         map[string]string
+	NullableTypeMappings map[string]string
+	// ImplicitNullable 为 true 时未声明 NOT NULL 的非主键列视为可空
+	ImplicitNullable bool
+	// ResolveType 可选，根据基础类型和括号内参数（如 "(10,2)"）返回Go类型，优先于映射表
+	ResolveType func(sqlType, params string, nullable bool) (string, bool)
+	// Placeholder 返回第 n 个（从1开始）参数的占位符
+	Placeholder func(n int) string
+	// QuoteIdent 返回加引号的标识符
+	QuoteIdent func(name string) string
+	// UpsertClause 返回 INSERT 之后的冲突更新子句，为 nil 表示不支持
+	UpsertClause func(d *Dialect, conflict, update []string) string
+	// CheckValidation 为 true 时把 CHECK 约束中的简单条件转为 validate 规则
+	CheckValidation bool
+}
+
+var dialects = map[string]*Dialect{
+	"mysql": {
+		Name:         "mysql",
+		Placeholder:  questionPlaceholder,
+		QuoteIdent:   backtickQuote,
+		UpsertClause: onDuplicateKeyUpdate,
+	},
+	// mysql8 从 8.0.16 起强制执行 CHECK 约束，因此生成对应的校验规则；
+	// 8.0.13 起允许的 DEFAULT (expr) 函数默认值与普通默认值一样处理
+	"mysql8": {
+		Name:            "mysql8",
+		Placeholder:     questionPlaceholder,
+		QuoteIdent:      backtickQuote,
+		UpsertClause:    onDuplicateKeyUpdate,
+		CheckValidation: true,
+	},
+	// mysql5 解析但不执行 CHECK 约束，不据此生成校验
+	"mysql5": {
+		Name:         "mysql5",
+		Placeholder:  questionPlaceholder,
+		QuoteIdent:   backtickQuote,
+		UpsertClause: onDuplicateKeyUpdate,
+	},
+	// postgres 列名可不加引号或使用双引号，列注释通过 COMMENT ON COLUMN 声明
+	"postgres": {
+		Name: "postgres",
+		TypeMappings: map[string]string{
+			"SMALLINT":                    "int16",
+			"INT2":                        "int16",
+			"INTEGER":                     "int32",
+			"INT":                         "int32",
+			"INT4":                        "int32",
+			"BIGINT":                      "int64",
+			"INT8":                        "int64",
+			"SMALLSERIAL":                 "int16",
+			"SERIAL":                      "int32",
+			"BIGSERIAL":                   "int64",
+			"REAL":                        "float32",
+			"FLOAT4":                      "float32",
+			"DOUBLE PRECISION":            "float64",
+			"FLOAT8":                      "float64",
+			"NUMERIC":                     "decimal.Decimal",
+			"DECIMAL":                     "decimal.Decimal",
+			"BOOLEAN":                     "bool",
+			"BOOL":                        "bool",
+			"TEXT":                        "string",
+			"VARCHAR":                     "string",
+			"CHARACTER VARYING":           "string",
+			"CHAR":                        "string",
+			"CHARACTER":                   "string",
+			"UUID":                        "string",
+			"INET":                        "string",
+			"INTERVAL":                    "string",
+			"JSON":                        "json.RawMessage",
+			"JSONB":                       "json.RawMessage",
+			"BYTEA":                       "[]byte",
+			"DATE":                        "time.Time",
+			"TIME":                        "time.Time",
+			"TIMESTAMP":                   "time.Time",
+			"TIMESTAMPTZ":                 "time.Time",
+			"TIMESTAMP WITH TIME ZONE":    "time.Time",
+			"TIMESTAMP WITHOUT TIME ZONE": "time.Time",
+		},
+		NullableTypeMappings: map[string]string{
+			"SMALLINT":                    "sql.NullInt16",
+			"INT2":                        "sql.NullInt16",
+			"INTEGER":                     "sql.NullInt32",
+			"INT":                         "sql.NullInt32",
+			"INT4":                        "sql.NullInt32",
+			"BIGINT":                      "sql.NullInt64",
+			"INT8":                        "sql.NullInt64",
+			"SMALLSERIAL":                 "sql.NullInt16",
+			"SERIAL":                      "sql.NullInt32",
+			"BIGSERIAL":                   "sql.NullInt64",
+			"REAL":                        "sql.NullFloat64",
+			"FLOAT4":                      "sql.NullFloat64",
+			"DOUBLE PRECISION":            "sql.NullFloat64",
+			"FLOAT8":                      "sql.NullFloat64",
+			"NUMERIC":                     "decimal.NullDecimal",
+			"DECIMAL":                     "decimal.NullDecimal",
+			"BOOLEAN":                     "sql.NullBool",
+			"BOOL":                        "sql.NullBool",
+			"TEXT":                        "sql.NullString",
+			"VARCHAR":                     "sql.NullString",
+			"CHARACTER VARYING":           "sql.NullString",
+			"CHAR":                        "sql.NullString",
+			"CHARACTER":                   "sql.NullString",
+			"UUID":                        "sql.NullString",
+			"INET":                        "sql.NullString",
+			"INTERVAL":                    "sql.NullString",
+			"JSON":                        "json.RawMessage",
+			"JSONB":                       "json.RawMessage",
+			"BYTEA":                       "[]byte",
+			"DATE":                        "sql.NullTime",
+			"TIME":                        "sql.NullTime",
+			"TIMESTAMP":                   "sql.NullTime",
+			"TIMESTAMPTZ":                 "sql.NullTime",
+			"TIMESTAMP WITH TIME ZONE":    "sql.NullTime",
+			"TIMESTAMP WITHOUT TIME ZONE": "sql.NullTime",
+		},
+		ImplicitNullable: true,
+		Placeholder:      dollarPlaceholder,
+		QuoteIdent:       doubleQuote,
+		UpsertClause:     onConflictUpdate,
+	},
+	// ansi 使用标准SQL类型名，标识符不加引号或使用双引号，适用于与具体数据库无关的DDL
+	"ansi": {
+		Name: "ansi",
+		TypeMappings: map[string]string{
+			"SMALLINT":                 "int16",
+			"INTEGER":                  "int32",
+			"INT":                      "int32",
+			"BIGINT":                   "int64",
+			"NUMERIC":                  "decimal.Decimal",
+			"DECIMAL":                  "decimal.Decimal",
+			"DEC":                      "decimal.Decimal",
+			"REAL":                     "float32",
+			"FLOAT":                    "float64",
+			"DOUBLE PRECISION":         "float64",
+			"BOOLEAN":                  "bool",
+			"CHARACTER":                "string",
+			"CHAR":                     "string",
+			"CHARACTER VARYING":        "string",
+			"CHAR VARYING":             "string",
+			"VARCHAR":                  "string",
+			"CHARACTER LARGE OBJECT":   "string",
+			"CLOB":                     "string",
+			"BINARY":                   "[]byte",
+			"BINARY VARYING":           "[]byte",
+			"VARBINARY":                "[]byte",
+			"BINARY LARGE OBJECT":      "[]byte",
+			"BLOB":                     "[]byte",
+			"DATE":                     "time.Time",
+			"TIME":                     "time.Time",
+			"TIMESTAMP":                "time.Time",
+			"TIMESTAMP WITH TIME ZONE": "time.Time",
+			"TIME WITH TIME ZONE":      "time.Time",
+		},
+		NullableTypeMappings: map[string]string{
+			"SMALLINT":                 "sql.NullInt16",
+			"INTEGER":                  "sql.NullInt32",
+			"INT":                      "sql.NullInt32",
+			"BIGINT":                   "sql.NullInt64",
+			"NUMERIC":                  "decimal.NullDecimal",
+			"DECIMAL":                  "decimal.NullDecimal",
+			"DEC":                      "decimal.NullDecimal",
+			"REAL":                     "sql.NullFloat64",
+			"FLOAT":                    "sql.NullFloat64",
+			"DOUBLE PRECISION":         "sql.NullFloat64",
+			"BOOLEAN":                  "sql.NullBool",
+			"CHARACTER":                "sql.NullString",
+			"CHAR":                     "sql.NullString",
+			"CHARACTER VARYING":        "sql.NullString",
+			"CHAR VARYING":             "sql.NullString",
+			"VARCHAR":                  "sql.NullString",
+			"CHARACTER LARGE OBJECT":   "sql.NullString",
+			"CLOB":                     "sql.NullString",
+			"BINARY":                   "[]byte",
+			"BINARY VARYING":           "[]byte",
+			"VARBINARY":                "[]byte",
+			"BINARY LARGE OBJECT":      "[]byte",
+			"BLOB":                     "[]byte",
+			"DATE":                     "sql.NullTime",
+			"TIME":                     "sql.NullTime",
+			"TIMESTAMP":                "sql.NullTime",
+			"TIMESTAMP WITH TIME ZONE": "sql.NullTime",
+			"TIME WITH TIME ZONE":      "sql.NullTime",
+		},
+		ImplicitNullable: true,
+		Placeholder:      questionPlaceholder,
+		QuoteIdent:       doubleQuote,
+		UpsertClause:     nil,
+	},
+	// duckdb HUGEINT 按 go-duckdb 驱动的扫描结果映射为 *big.Int，无符号整数没有对应的 sql.Null* 类型，可空时使用指针
+	"duckdb": {
+		Name: "duckdb",
+		TypeMappings: map[string]string{
+			"TINYINT":   "int8",
+			"SMALLINT":  "int16",
+			"INTEGER":   "int32",
+			"INT":       "int32",
+			"BIGINT":    "int64",
+			"HUGEINT":   "*big.Int",
+			"UTINYINT":  "uint8",
+			"USMALLINT": "uint16",
+			"UINTEGER":  "uint32",
+			"UBIGINT":   "uint64",
+			"FLOAT":     "float32",
+			"REAL":      "float32",
+			"DOUBLE":    "float64",
+			"VARCHAR":   "string",
+			"TEXT":      "string",
+			"STRING":    "string",
+			"UUID":      "string",
+			"JSON":      "string",
+			"BOOLEAN":   "bool",
+			"BOOL":      "bool",
+			"BLOB":      "[]byte",
+			"DATE":      "time.Time",
+			"TIME":      "time.Time",
+			"TIMESTAMP": "time.Time",
+		},
+		NullableTypeMappings: map[string]string{
+			"TINYINT":   "sql.NullInt16",
+			"SMALLINT":  "sql.NullInt16",
+			"INTEGER":   "sql.NullInt32",
+			"INT":       "sql.NullInt32",
+			"BIGINT":    "sql.NullInt64",
+			"HUGEINT":   "*big.Int",
+			"UTINYINT":  "*uint8",
+			"USMALLINT": "*uint16",
+			"UINTEGER":  "*uint32",
+			"UBIGINT":   "*uint64",
+			"FLOAT":     "sql.NullFloat64",
+			"REAL":      "sql.NullFloat64",
+			"DOUBLE":    "sql.NullFloat64",
+			"VARCHAR":   "sql.NullString",
+			"TEXT":      "sql.NullString",
+			"STRING":    "sql.NullString",
+			"UUID":      "sql.NullString",
+			"JSON":      "sql.NullString",
+			"BOOLEAN":   "sql.NullBool",
+			"BOOL":      "sql.NullBool",
+			"BLOB":      "[]byte",
+			"DATE":      "sql.NullTime",
+			"TIME":      "sql.NullTime",
+			"TIMESTAMP": "sql.NullTime",
+		},
+		ImplicitNullable: true,
+		Placeholder:      dollarPlaceholder,
+		QuoteIdent:       doubleQuote,
+		UpsertClause:     onConflictUpdate,
+	},
+	// cockroach 的 INT 默认为64位，统一映射为 int64
+	"cockroach": {
+		Name: "cockroach",
+		TypeMappings: map[string]string{
+			"INT":         "int64",
+			"INTEGER":     "int64",
+			"INT8":        "int64",
+			"INT4":        "int32",
+			"INT2":        "int16",
+			"BIGINT":      "int64",
+			"SMALLINT":    "int16",
+			"SERIAL":      "int64",
+			"FLOAT":       "float64",
+			"FLOAT8":      "float64",
+			"FLOAT4":      "float32",
+			"REAL":        "float32",
+			"STRING":      "string",
+			"TEXT":        "string",
+			"VARCHAR":     "string",
+			"CHAR":        "string",
+			"BYTES":       "[]byte",
+			"BYTEA":       "[]byte",
+			"BOOL":        "bool",
+			"BOOLEAN":     "bool",
+			"UUID":        "uuid.UUID",
+			"DATE":        "time.Time",
+			"TIMESTAMP":   "time.Time",
+			"TIMESTAMPTZ": "time.Time",
+			"JSONB":       "json.RawMessage",
+			"JSON":        "json.RawMessage",
+		},
+		NullableTypeMappings: map[string]string{
+			"INT":         "sql.NullInt64",
+			"INTEGER":     "sql.NullInt64",
+			"INT8":        "sql.NullInt64",
+			"INT4":        "sql.NullInt32",
+			"INT2":        "sql.NullInt16",
+			"BIGINT":      "sql.NullInt64",
+			"SMALLINT":    "sql.NullInt16",
+			"SERIAL":      "sql.NullInt64",
+			"FLOAT":       "sql.NullFloat64",
+			"FLOAT8":      "sql.NullFloat64",
+			"FLOAT4":      "sql.NullFloat64",
+			"REAL":        "sql.NullFloat64",
+			"STRING":      "sql.NullString",
+			"TEXT":        "sql.NullString",
+			"VARCHAR":     "sql.NullString",
+			"CHAR":        "sql.NullString",
+			"BYTES":       "[]byte",
+			"BYTEA":       "[]byte",
+			"BOOL":        "sql.NullBool",
+			"BOOLEAN":     "sql.NullBool",
+			"UUID":        "uuid.NullUUID",
+			"DATE":        "sql.NullTime",
+			"TIMESTAMP":   "sql.NullTime",
+			"TIMESTAMPTZ": "sql.NullTime",
+			"JSONB":       "json.RawMessage",
+			"JSON":        "json.RawMessage",
+		},
+		ImplicitNullable: true,
+		Placeholder:      dollarPlaceholder,
+		QuoteIdent:       doubleQuote,
+		UpsertClause:     onConflictUpdate,
+	},
+	// snowflake 的整数均为 NUMBER(38,0)，NUMBER 按小数位决定映射，半结构化类型映射为 json.RawMessage
+	"snowflake": {
+		Name: "snowflake",
+		TypeMappings: map[string]string{
+			"NUMBER":        "int64",
+			"DECIMAL":       "int64",
+			"NUMERIC":       "int64",
+			"INT":           "int64",
+			"INTEGER":       "int64",
+			"BIGINT":        "int64",
+			"SMALLINT":      "int64",
+			"TINYINT":       "int64",
+			"BYTEINT":       "int64",
+			"FLOAT":         "float64",
+			"FLOAT4":        "float64",
+			"FLOAT8":        "float64",
+			"DOUBLE":        "float64",
+			"REAL":          "float64",
+			"VARCHAR":       "string",
+			"STRING":        "string",
+			"TEXT":          "string",
+			"CHAR":          "string",
+			"CHARACTER":     "string",
+			"BOOLEAN":       "bool",
+			"BINARY":        "[]byte",
+			"VARBINARY":     "[]byte",
+			"DATE":          "time.Time",
+			"TIME":          "time.Time",
+			"DATETIME":      "time.Time",
+			"TIMESTAMP":     "time.Time",
+			"TIMESTAMP_NTZ": "time.Time",
+			"TIMESTAMP_LTZ": "time.Time",
+			"TIMESTAMP_TZ":  "time.Time",
+			"VARIANT":       "json.RawMessage",
+			"OBJECT":        "json.RawMessage",
+			"ARRAY":         "json.RawMessage",
+			"GEOGRAPHY":     "string",
+		},
+		NullableTypeMappings: map[string]string{
+			"NUMBER":        "sql.NullInt64",
+			"DECIMAL":       "sql.NullInt64",
+			"NUMERIC":       "sql.NullInt64",
+			"INT":           "sql.NullInt64",
+			"INTEGER":       "sql.NullInt64",
+			"BIGINT":        "sql.NullInt64",
+			"SMALLINT":      "sql.NullInt64",
+			"TINYINT":       "sql.NullInt64",
+			"BYTEINT":       "sql.NullInt64",
+			"FLOAT":         "sql.NullFloat64",
+			"FLOAT4":        "sql.NullFloat64",
+			"FLOAT8":        "sql.NullFloat64",
+			"DOUBLE":        "sql.NullFloat64",
+			"REAL":          "sql.NullFloat64",
+			"VARCHAR":       "sql.NullString",
+			"STRING":        "sql.NullString",
+			"TEXT":          "sql.NullString",
+			"CHAR":          "sql.NullString",
+			"CHARACTER":     "sql.NullString",
+			"BOOLEAN":       "sql.NullBool",
+			"BINARY":        "[]byte",
+			"VARBINARY":     "[]byte",
+			"DATE":          "sql.NullTime",
+			"TIME":          "sql.NullTime",
+			"DATETIME":      "sql.NullTime",
+			"TIMESTAMP":     "sql.NullTime",
+			"TIMESTAMP_NTZ": "sql.NullTime",
+			"TIMESTAMP_LTZ": "sql.NullTime",
+			"TIMESTAMP_TZ":  "sql.NullTime",
+			"VARIANT":       "json.RawMessage",
+			"OBJECT":        "json.RawMessage",
+			"ARRAY":         "json.RawMessage",
+			"GEOGRAPHY":     "sql.NullString",
+		},
+		ImplicitNullable: true,
+		ResolveType:      resolveNumberScale,
+		Placeholder:      questionPlaceholder,
+		QuoteIdent:       doubleQuote,
+		UpsertClause:     nil,
+	},
+	// bigquery 的类型与 cloud.google.com/go/bigquery 客户端的扫描类型保持一致，可空列使用 bigquery.Null* 类型
+	"bigquery": {
+		Name: "bigquery",
+		TypeMappings: map[string]string{
+			"STRING":     "string",
+			"INT64":      "int64",
+			"INT":        "int64",
+			"INTEGER":    "int64",
+			"BIGINT":     "int64",
+			"FLOAT64":    "float64",
+			"NUMERIC":    "*big.Rat",
+			"BIGNUMERIC": "*big.Rat",
+			"BOOL":       "bool",
+			"BOOLEAN":    "bool",
+			"BYTES":      "[]byte",
+			"TIMESTAMP":  "time.Time",
+			"DATE":       "civil.Date",
+			"DATETIME":   "civil.DateTime",
+			"TIME":       "civil.Time",
+			"JSON":       "string",
+			"GEOGRAPHY":  "string",
+		},
+		NullableTypeMappings: map[string]string{
+			"STRING":     "bigquery.NullString",
+			"INT64":      "bigquery.NullInt64",
+			"INT":        "bigquery.NullInt64",
+			"INTEGER":    "bigquery.NullInt64",
+			"BIGINT":     "bigquery.NullInt64",
+			"FLOAT64":    "bigquery.NullFloat64",
+			"NUMERIC":    "*big.Rat",
+			"BIGNUMERIC": "*big.Rat",
+			"BOOL":       "bigquery.NullBool",
+			"BOOLEAN":    "bigquery.NullBool",
+			"BYTES":      "[]byte",
+			"TIMESTAMP":  "bigquery.NullTimestamp",
+			"DATE":       "bigquery.NullDate",
+			"DATETIME":   "bigquery.NullDateTime",
+			"TIME":       "bigquery.NullTime",
+			"JSON":       "bigquery.NullJSON",
+			"GEOGRAPHY":  "bigquery.NullGeography",
+		},
+		ImplicitNullable: true,
+		Placeholder:      questionPlaceholder,
+		QuoteIdent:       backtickQuote,
+		UpsertClause:     nil,
+	},
+	// redshift 的DDL与 postgres 相近，ENCODE、DISTKEY、SORTKEY 等列选项不影响映射；
+	// 不支持 INSERT ... ON CONFLICT，不生成 upsert
+	"redshift": {
+		Name: "redshift",
+		TypeMappings: map[string]string{
+			"SMALLINT":                    "int16",
+			"INT2":                        "int16",
+			"INTEGER":                     "int32",
+			"INT":                         "int32",
+			"INT4":                        "int32",
+			"BIGINT":                      "int64",
+			"INT8":                        "int64",
+			"REAL":                        "float32",
+			"FLOAT4":                      "float32",
+			"DOUBLE PRECISION":            "float64",
+			"FLOAT8":                      "float64",
+			"FLOAT":                       "float64",
+			"DECIMAL":                     "decimal.Decimal",
+			"NUMERIC":                     "decimal.Decimal",
+			"BOOLEAN":                     "bool",
+			"BOOL":                        "bool",
+			"CHAR":                        "string",
+			"CHARACTER":                   "string",
+			"NCHAR":                       "string",
+			"BPCHAR":                      "string",
+			"VARCHAR":                     "string",
+			"CHARACTER VARYING":           "string",
+			"NVARCHAR":                    "string",
+			"TEXT":                        "string",
+			"SUPER":                       "json.RawMessage",
+			"VARBYTE":                     "[]byte",
+			"VARBINARY":                   "[]byte",
+			"BINARY VARYING":              "[]byte",
+			"GEOMETRY":                    "[]byte",
+			"GEOGRAPHY":                   "[]byte",
+			"DATE":                        "time.Time",
+			"TIME":                        "time.Time",
+			"TIMETZ":                      "time.Time",
+			"TIMESTAMP":                   "time.Time",
+			"TIMESTAMPTZ":                 "time.Time",
+			"TIMESTAMP WITH TIME ZONE":    "time.Time",
+			"TIMESTAMP WITHOUT TIME ZONE": "time.Time",
+		},
+		NullableTypeMappings: map[string]string{
+			"SMALLINT":                    "sql.NullInt16",
+			"INT2":                        "sql.NullInt16",
+			"INTEGER":                     "sql.NullInt32",
+			"INT":                         "sql.NullInt32",
+			"INT4":                        "sql.NullInt32",
+			"BIGINT":                      "sql.NullInt64",
+			"INT8":                        "sql.NullInt64",
+			"REAL":                        "sql.NullFloat64",
+			"FLOAT4":                      "sql.NullFloat64",
+			"DOUBLE PRECISION":            "sql.NullFloat64",
+			"FLOAT8":                      "sql.NullFloat64",
+			"FLOAT":                       "sql.NullFloat64",
+			"DECIMAL":                     "decimal.NullDecimal",
+			"NUMERIC":                     "decimal.NullDecimal",
+			"BOOLEAN":                     "sql.NullBool",
+			"BOOL":                        "sql.NullBool",
+			"CHAR":                        "sql.NullString",
+			"CHARACTER":                   "sql.NullString",
+			"NCHAR":                       "sql.NullString",
+			"BPCHAR":                      "sql.NullString",
+			"VARCHAR":                     "sql.NullString",
+			"CHARACTER VARYING":           "sql.NullString",
+			"NVARCHAR":                    "sql.NullString",
+			"TEXT":                        "sql.NullString",
+			"SUPER":                       "json.RawMessage",
+			"VARBYTE":                     "[]byte",
+			"VARBINARY":                   "[]byte",
+			"BINARY VARYING":              "[]byte",
+			"GEOMETRY":                    "[]byte",
+			"GEOGRAPHY":                   "[]byte",
+			"DATE":                        "sql.NullTime",
+			"TIME":                        "sql.NullTime",
+			"TIMETZ":                      "sql.NullTime",
+			"TIMESTAMP":                   "sql.NullTime",
+			"TIMESTAMPTZ":                 "sql.NullTime",
+			"TIMESTAMP WITH TIME ZONE":    "sql.NullTime",
+			"TIMESTAMP WITHOUT TIME ZONE": "sql.NullTime",
+		},
+		ImplicitNullable: true,
+		Placeholder:      dollarPlaceholder,
+		QuoteIdent:       doubleQuote,
+		UpsertClause:     nil,
+	},
+	// sqlite 是动态类型，驱动按存储类扫描为 int64、float64、string 或 []byte，
+	// 映射表之外的类型名按 SQLite 的类型亲和性规则推导，见 resolveSQLiteAffinity
+	"sqlite": {
+		Name:                 "sqlite",
+		TypeMappings:         sqliteTypeMappings,
+		NullableTypeMappings: sqliteNullableTypeMappings,
+		ImplicitNullable:     true,
+		ResolveType:          resolveSQLiteAffinity,
+		Placeholder:          questionPlaceholder,
+		QuoteIdent:           doubleQuote,
+		UpsertClause:         onConflictUpdate,
+	},
+}
+
+// sqliteTypeMappings BOOLEAN 和日期时间类型按 mattn/go-sqlite3 的约定映射为 bool、time.Time
+var sqliteTypeMappings = map[string]string{
+	"INTEGER":   "int64",
+	"INT":       "int64",
+	"BIGINT":    "int64",
+	"REAL":      "float64",
+	"DOUBLE":    "float64",
+	"FLOAT":     "float64",
+	"NUMERIC":   "float64",
+	"DECIMAL":   "float64",
+	"TEXT":      "string",
+	"VARCHAR":   "string",
+	"CHAR":      "string",
+	"CLOB":      "string",
+	"BLOB":      "[]byte",
+	"BOOLEAN":   "bool",
+	"BOOL":      "bool",
+	"DATE":      "time.Time",
+	"DATETIME":  "time.Time",
+	"TIMESTAMP": "time.Time",
+}
+
+var sqliteNullableTypeMappings = map[string]string{
+	"INTEGER":   "sql.NullInt64",
+	"INT":       "sql.NullInt64",
+	"BIGINT":    "sql.NullInt64",
+	"REAL":      "sql.NullFloat64",
+	"DOUBLE":    "sql.NullFloat64",
+	"FLOAT":     "sql.NullFloat64",
+	"NUMERIC":   "sql.NullFloat64",
+	"DECIMAL":   "sql.NullFloat64",
+	"TEXT":      "sql.NullString",
+	"VARCHAR":   "sql.NullString",
+	"CHAR":      "sql.NullString",
+	"CLOB":      "sql.NullString",
+	"BLOB":      "[]byte",
+	"BOOLEAN":   "sql.NullBool",
+	"BOOL":      "sql.NullBool",
+	"DATE":      "sql.NullTime",
+	"DATETIME":  "sql.NullTime",
+	"TIMESTAMP": "sql.NullTime",
+}
+
+// resolveSQLiteAffinity 映射表之外的类型名按 SQLite 亲和性规则依次判断：
+// 含 INT 为整数，含 CHAR/CLOB/TEXT 为文本，含 BLOB 为二进制，含 REAL/FLOA/DOUB 为浮点数，其余为 NUMERIC 按浮点数处理
+func resolveSQLiteAffinity(sqlType, params string, nullable bool) (string, bool) {
+	if _, ok := sqliteTypeMappings[sqlType]; ok {
+		return "", false
+	}
+	affinity := "NUMERIC"
+	switch {
+	case strings.Contains(sqlType, "INT"):
+		affinity = "INTEGER"
+	case strings.Contains(sqlType, "CHAR") || strings.Contains(sqlType, "CLOB") || strings.Contains(sqlType, "TEXT"):
+		affinity = "TEXT"
+	case strings.Contains(sqlType, "BLOB"):
+		affinity = "BLOB"
+	case strings.Contains(sqlType, "REAL") || strings.Contains(sqlType, "FLOA") || strings.Contains(sqlType, "DOUB"):
+		affinity = "REAL"
+	}
+	if nullable {
+		return sqliteNullableTypeMappings[affinity], true
+	}
+	return sqliteTypeMappings[affinity], true
+}
+
+// resolveNumberScale 带小数位的 NUMBER/DECIMAL/NUMERIC 映射为浮点数，其余交给映射表
+func resolveNumberScale(sqlType, params string, nullable bool) (string, bool) {
+	if sqlType != "NUMBER" && sqlType != "DECIMAL" && sqlType != "NUMERIC" {
+		return "", false
+	}
+	parts := strings.Split(strings.Trim(params, "() "), ",")
+	if len(parts) < 2 || strings.TrimSpace(parts[1]) == "0" {
+		return "", false
+	}
+	if nullable {
+		return "sql.NullFloat64", true
+	}
+	return "float64", true
+}
+
+func questionPlaceholder(int) string { return "?" }
+
+func dollarPlaceholder(n int) string { return fmt.Sprintf("$%d", n) }
+
+func backtickQuote(name string) string { return "`" + name + "`" }
+
+func doubleQuote(name string) string { return `"` + name + `"` }
+
+// onDuplicateKeyUpdate MySQL 风格的 upsert 子句
+func onDuplicateKeyUpdate(d *Dialect, conflict, update []string) string {
+	if len(update) == 0 {
+		// 没有可更新的列时用主键自赋值实现忽略
+		update = conflict[:1]
+	}
+	sets := make([]string, len(update))
+	for i, col := range update {
+		sets[i] = fmt.Sprintf("%s = VALUES(%s)", d.QuoteIdent(col), d.QuoteIdent(col))
+	}
+	return "ON DUPLICATE KEY UPDATE " + strings.Join(sets, ", ")
+}
+
+// onConflictUpdate Postgres 风格的 upsert 子句
+func onConflictUpdate(d *Dialect, conflict, update []string) string {
+	quoted := make([]string, len(conflict))
+	for i, col := range conflict {
+		quoted[i] = d.QuoteIdent(col)
+	}
+	if len(update) == 0 {
+		return fmt.Sprintf("ON CONFLICT (%s) DO NOTHING", strings.Join(quoted, ", "))
+	}
+	sets := make([]string, len(update))
+	for i, col := range update {
+		sets[i] = fmt.Sprintf("%s = EXCLUDED.%s", d.QuoteIdent(col), d.QuoteIdent(col))
+	}
+	return fmt.Sprintf("ON CONFLICT (%s) DO UPDATE SET %s", strings.Join(quoted, ", "), strings.Join(sets, ", "))
+}
+
+// SetDialect 切换方言，方言提供类型映射时替换默认映射
+func (p *SQLParser) SetDialect(d *Dialect) {
+	p.Dialect = d
+	if d.TypeMappings != nil {
+		p.TypeMappings = copyMappings(d.TypeMappings)
+	}
+	if d.NullableTypeMappings != nil {
+		p.NullableTypeMappings = copyMappings(d.NullableTypeMappings)
+	}
+}
+
+func copyMappings(mappings map[string]string) map[string]string {
+	copied := make(map[string]string, len(mappings))
+	for k, v := range mappings {
+		copied[k] = v
+	}
+	return copied
+}
+
+// LookupDialect 按名称查找方言，名称不区分大小写
+func LookupDialect(name string) (*Dialect, error) {
+	dialect, ok := dialects[strings.ToLower(name)]
+	if !ok {
+		names := make([]string, 0, len(dialects))
+		for n := range dialects {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("不支持的方言: %s（可选: %s）", name, strings.Join(names, ", "))
+	}
+	return dialect, nil
+}
+
+// placeholders 返回 n 个以逗号分隔的占位符
+func (d *Dialect) placeholders(n int) string {
+	parts := make([]string, n)
+	for i := range parts {
+		parts[i] = d.Placeholder(i + 1)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// upsertSQL 生成插入或按冲突键更新的语句
+func (d *Dialect) upsertSQL(table string, columns, conflict []string) string {
+	isConflict := make(map[string]bool, len(conflict))
+	for _, col := range conflict {
+		isConflict[col] = true
+	}
+	quoted := make([]string, len(columns))
+	var update []string
+	for i, col := range columns {
+		quoted[i] = d.QuoteIdent(col)
+		if !isConflict[col] {
+			update = append(update, col)
+		}
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) %s",
+		d.QuoteIdent(table), strings.Join(quoted, ", "), d.placeholders(len(columns)),
+		d.UpsertClause(d, conflict, update))
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// EnumValue 枚举取值及其说明
+type EnumValue struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+}
+
+// defaultEnumCommentPattern 匹配注释中 0-关闭、1:开启 形式的取值说明
+const defaultEnumCommentPattern = `(-?\d+)\s*[-:：=]\s*([^,，;；、\s]+)`
+
+var (
+	defaultEnumCommentRegex = regexp.MustCompile(defaultEnumCommentPattern)
+	enumMemberRegex         = regexp.MustCompile(`'((?:[^']|'')*)'`)
+	enumIdentRegex          = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_ ]*$`)
+)
+
+// compileEnumCommentRegex 编译 --enum-comment-regex，要求第一个分组为取值、第二个分组为说明
+func compileEnumCommentRegex(pattern string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("解析 --enum-comment-regex 失败: %w", err)
+	}
+	if re.NumSubexp() < 2 {
+		return nil, fmt.Errorf("--enum-comment-regex 需要两个捕获分组(取值、说明): %s", pattern)
+	}
+	return re, nil
+}
+
+// parseEnumComment 从注释中提取数值枚举的取值说明，少于两个取值时视为普通注释
+func (p *SQLParser) parseEnumComment(comment string) []EnumValue {
+	re := p.EnumCommentRegex
+	if re == nil {
+		re = defaultEnumCommentRegex
+	}
+	matches := re.FindAllStringSubmatch(comment, -1)
+	if len(matches) < 2 {
+		return nil
+	}
+	values := make([]EnumValue, 0, len(matches))
+	for _, match := range matches {
+		if _, err := strconv.ParseInt(match[1], 10, 64); err != nil {
+			continue
+		}
+		values = append(values, EnumValue{Value: match[1], Label: match[2]})
+	}
+	if len(values) < 2 {
+		return nil
+	}
+	return values
+}
+
+// parseEnumMembers 解析 ENUM('a','b') 的成员，取值为 MySQL 中从1开始的序号
+func parseEnumMembers(params string) []EnumValue {
+	var values []EnumValue
+	for i, match := range enumMemberRegex.FindAllStringSubmatch(params, -1) {
+		values = append(values, EnumValue{
+			Value: strconv.Itoa(i + 1),
+			Label: strings.ReplaceAll(match[1], "''", "'"),
+		})
+	}
+	return values
+}
+
+// isIntegerType 判断Go类型是否为整数
+func isIntegerType(goType string) bool {
+	switch goType {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return true
+	}
+	return false
+}
+
+// writeEnumMaps 为带取值说明的列生成取值与说明之间的双向映射
+func (p *SQLParser) writeEnumMaps(builder *strings.Builder) {
+	for _, field := range p.Fields {
+		if field.Ignored || len(field.EnumValues) == 0 {
+			continue
+		}
+		keyType := basicType(field.FieldType)
+		if !isIntegerType(keyType) {
+			// ENUM 列使用 MySQL 的成员序号
+			keyType = "int32"
+		}
+		labels := p.StructName + field.FieldName + "Labels"
+		values := p.StructName + field.FieldName + "Values"
+
+		builder.WriteString(fmt.Sprintf("// %s %s 的取值与说明\n", labels, field.OriginalField))
+		builder.WriteString(fmt.Sprintf("var %s = map[%s]string{\n", labels, keyType))
+		for _, value := range field.EnumValues {
+			builder.WriteString(fmt.Sprintf("\t%s: %q,\n", value.Value, value.Label))
+		}
+		builder.WriteString("}\n\n")
+
+		builder.WriteString(fmt.Sprintf("// %s %s 的反向映射\n", values, labels))
+		builder.WriteString(fmt.Sprintf("var %s = map[string]%s{\n", values, keyType))
+		for _, value := range field.EnumValues {
+			builder.WriteString(fmt.Sprintf("\t%q: %s,\n", value.Label, value.Value))
+		}
+		builder.WriteString("}\n\n")
+	}
+}
+
+// enumConstName 生成枚举常量名，说明不是英文单词时退回到取值
+func enumConstName(prefix string, value EnumValue) string {
+	if enumIdentRegex.MatchString(value.Label) {
+		return prefix + ToPascalCase(strings.ToLower(strings.ReplaceAll(value.Label, " ", "_")))
+	}
+	return prefix + "V" + strings.Replace(value.Value, "-", "Neg", 1)
+}
+
+// writeEnumConsts 为 ENUM 列和注释中记录取值说明的整数列生成具名类型和常量
+func (p *SQLParser) writeEnumConsts(builder *strings.Builder) {
+	for _, field := range p.Fields {
+		if field.Ignored || len(field.EnumValues) == 0 {
+			continue
+		}
+		prefix := p.StructName + field.FieldName
+		typeName := prefix + "Type"
+		isEnum := sqlBaseType(field.SQLType) == "ENUM"
+
+		builder.WriteString(fmt.Sprintf("// %s %s 的取值\n", typeName, field.OriginalField))
+		if isEnum {
+			builder.WriteString(fmt.Sprintf("type %s string\n\n", typeName))
+		} else {
+			builder.WriteString(fmt.Sprintf("type %s %s\n\n", typeName, basicType(field.FieldType)))
+		}
+		builder.WriteString("const (\n")
+		for _, value := range field.EnumValues {
+			if isEnum {
+				builder.WriteString(fmt.Sprintf("\t%s %s = %q\n", enumConstName(prefix, value), typeName, value.Label))
+				continue
+			}
+			builder.WriteString(fmt.Sprintf("\t%s %s = %s // %s\n", enumConstName(prefix, value), typeName, value.Value, value.Label))
+		}
+		builder.WriteString(")\n\n")
+	}
+}
+
+// oneOfRule 生成 ENUM 成员的 validate oneof 规则，含空格的成员用单引号包裹
+func oneOfRule(members []EnumValue) string {
+	labels := make([]string, len(members))
+	for i, member := range members {
+		labels[i] = member.Label
+		if strings.Contains(member.Label, " ") {
+			labels[i] = "'" + member.Label + "'"
+		}
+	}
+	return "oneof=" + strings.Join(labels, " ")
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// 退出码约定，便于脚本和 CI 区分失败原因
+const (
+	exitOK            = 0
+	exitError         = 1 // 解析或读写失败
+	exitUnmappedType  = 2 // --strict 下存在没有类型映射的列
+	exitNameCollision = 3 // 多个列生成了相同的字段名
+)
+
+// UnmappedTypeError 严格模式下存在没有对应Go类型的列
+type UnmappedTypeError struct {
+	Columns []string
+}
+
+func (e *UnmappedTypeError) Error() string {
+	return fmt.Sprintf("以下列的类型没有对应的Go类型: %s", strings.Join(e.Columns, ", "))
+}
+
+// NameCollisionError 多个列转换后得到相同的字段名
+type NameCollisionError struct {
+	Field   string
+	Columns []string
+}
+
+func (e *NameCollisionError) Error() string {
+	return fmt.Sprintf("列 %s 生成了相同的字段名 %s", strings.Join(e.Columns, ", "), e.Field)
+}
+
+// exitCode 返回错误对应的退出码
+func exitCode(err error) int {
+	var unmapped *UnmappedTypeError
+	var collision *NameCollisionError
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.As(err, &unmapped):
+		return exitUnmappedType
+	case errors.As(err, &collision):
+		return exitNameCollision
+	default:
+		return exitError
+	}
+}
+
+// checkUnmappedTypes 在严格模式下拒绝没有类型映射的列
+func (p *SQLParser) checkUnmappedTypes() error {
+	var columns []string
+	for _, field := range p.Fields {
+		if field.FieldType == "" {
+			columns = append(columns, fmt.Sprintf("%s(%s)", field.OriginalField, field.SQLType))
+		}
+	}
+	if len(columns) > 0 {
+		return &UnmappedTypeError{Columns: columns}
+	}
+	return nil
+}
+
+// checkNameCollisions 检查不同列是否生成了相同的字段名，如 user_id 和 userId
+func (p *SQLParser) checkNameCollisions() error {
+	seen := make(map[string]string, len(p.Fields))
+	for _, field := range p.Fields {
+		if column, ok := seen[field.FieldName]; ok {
+			return &NameCollisionError{Field: field.FieldName, Columns: []string{column, field.OriginalField}}
+		}
+		seen[field.FieldName] = field.OriginalField
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// writeFilter 生成指针字段的过滤结构体及构造参数化 WHERE 子句的方法
+func (p *SQLParser) writeFilter(builder *strings.Builder) {
+	filter := p.StructName + "Filter"
+	builder.WriteString(fmt.Sprintf("// %s %s 的查询条件，非 nil 字段参与过滤\n", filter, p.StructName))
+	builder.WriteString(fmt.Sprintf("type %s struct {\n", filter))
+	for _, field := range p.Fields {
+		if field.Ignored {
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("\t%-30s *%-20s // %s\n", field.FieldName, basicType(field.FieldType), field.Comment))
+	}
+	builder.WriteString("}\n\n")
+
+	// 编号占位符（如 $1）需要在运行时根据参数个数生成
+	placeholder := p.Dialect.Placeholder(1)
+	numbered := strings.HasSuffix(placeholder, "1")
+
+	builder.WriteString("// Where 根据非 nil 字段生成参数化的 WHERE 子句，没有条件时返回空串\n")
+	builder.WriteString(fmt.Sprintf("func (f %s) Where() (string, []interface{}) {\n", filter))
+	builder.WriteString("\tvar conds []string\n\tvar args []interface{}\n")
+	for _, field := range p.Fields {
+		if field.Ignored {
+			continue
+		}
+		column := p.Dialect.QuoteIdent(field.OriginalField)
+		builder.WriteString(fmt.Sprintf("\tif f.%s != nil {\n", field.FieldName))
+		builder.WriteString(fmt.Sprintf("\t\targs = append(args, *f.%s)\n", field.FieldName))
+		if numbered {
+			format := fmt.Sprintf("%s = %s%%d", column, strings.TrimSuffix(placeholder, "1"))
+			builder.WriteString(fmt.Sprintf("\t\tconds = append(conds, fmt.Sprintf(%q, len(args)))\n", format))
+		} else {
+			builder.WriteString(fmt.Sprintf("\t\tconds = append(conds, %q)\n", column+" = "+placeholder))
+		}
+		builder.WriteString("\t}\n")
+	}
+	builder.WriteString("\tif len(conds) == 0 {\n\t\treturn \"\", nil\n\t}\n")
+	builder.WriteString("\treturn \"WHERE \" + strings.Join(conds, \" AND \"), args\n}\n\n")
+}
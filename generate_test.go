@@ -0,0 +1,634 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"unicode"
+)
+
+var update = flag.Bool("update", false, "更新 testdata/golden 下的期望输出")
+
+// testPOImportPath 测试中PO包的导入路径，entity 文件通过它引用PO
+const testPOImportPath = "example.com/gen/po"
+
+// runGenerate 把 sql 写入临时文件后以命令行参数运行生成，返回按文件名索引的生成文件内容
+func runGenerate(t *testing.T, sql string, args ...string) map[string]string {
+	t.Helper()
+	dir := t.TempDir()
+	sqlFile := filepath.Join(dir, "schema.sql")
+	if err := os.WriteFile(sqlFile, []byte(sql), 0644); err != nil {
+		t.Fatal(err)
+	}
+	outputDir := filepath.Join(dir, "out")
+	argv := append([]string{"sql2struct", "--sql", sqlFile, "--output", outputDir,
+		"--module-path", testPOImportPath}, args...)
+	if err := newApp().Run(argv); err != nil {
+		t.Fatalf("生成失败: %v", err)
+	}
+	return readGenerated(t, outputDir)
+}
+
+// readGenerated 读取目录下生成的 .go 文件
+func readGenerated(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string]string, len(paths))
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[filepath.Base(path)] = string(content)
+	}
+	return files
+}
+
+// assertGolden 比较生成的文件与 testdata/golden/<name>/ 下的期望输出，-update 时改为写入
+func assertGolden(t *testing.T, name string, files map[string]string) {
+	t.Helper()
+	dir := filepath.Join("testdata", "golden", name)
+	if *update {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		for fileName, content := range files {
+			if err := os.WriteFile(filepath.Join(dir, fileName+".golden"), []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.golden"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != len(files) {
+		t.Errorf("生成了 %d 个文件，期望 %d 个: %v", len(files), len(paths), sortedKeys(files))
+	}
+	for _, path := range paths {
+		fileName := strings.TrimSuffix(filepath.Base(path), ".golden")
+		want, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, ok := files[fileName]
+		if !ok {
+			t.Errorf("缺少生成文件 %s", fileName)
+			continue
+		}
+		if got != string(want) {
+			t.Errorf("%s 与期望输出不一致（使用 -update 更新）:\n%s", fileName, got)
+		}
+	}
+}
+
+// assertGofmt 检查生成的文件已是 gofmt 格式，即与 format.Source 的结果逐字节一致
+func assertGofmt(t *testing.T, files map[string]string) {
+	t.Helper()
+	for _, fileName := range sortedKeys(files) {
+		formatted, err := format.Source([]byte(files[fileName]))
+		if err != nil {
+			t.Errorf("%s 格式化失败: %v", fileName, err)
+			continue
+		}
+		if string(formatted) != files[fileName] {
+			t.Errorf("%s 不是 gofmt 格式:\n%s", fileName, formatted)
+		}
+	}
+}
+
+func sortedKeys(files map[string]string) []string {
+	keys := make([]string, 0, len(files))
+	for key := range files {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// stdImporter 标准库的导入器，各测试共用以避免重复加载
+var (
+	stdImporterOnce sync.Once
+	stdImporter     types.Importer
+)
+
+// sourceImporter 按导入路径依次查找生成的包、testdata/stubs 下的桩代码和标准库
+type sourceImporter struct {
+	fset      *token.FileSet
+	generated map[string][]*ast.File
+	packages  map[string]*types.Package
+	// errs 生成代码中的全部类型错误
+	errs []error
+}
+
+func (im *sourceImporter) Import(path string) (*types.Package, error) {
+	if pkg, ok := im.packages[path]; ok {
+		return pkg, nil
+	}
+	conf := types.Config{Importer: im}
+	files, ok := im.generated[path]
+	if ok {
+		conf.Error = func(err error) { im.errs = append(im.errs, err) }
+	} else {
+		stubDir := filepath.Join("testdata", "stubs", filepath.FromSlash(path))
+		if _, err := os.Stat(stubDir); err != nil {
+			stdImporterOnce.Do(func() { stdImporter = importer.Default() })
+			return stdImporter.Import(path)
+		}
+		paths, err := filepath.Glob(filepath.Join(stubDir, "*.go"))
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range paths {
+			file, err := parser.ParseFile(im.fset, p, nil, 0)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, file)
+		}
+	}
+	pkg, err := conf.Check(path, im.fset, files, nil)
+	if err != nil && !ok {
+		return nil, err
+	}
+	im.packages[path] = pkg
+	return pkg, nil
+}
+
+// assertCompiles 对生成的文件做类型检查，文件按 package 分组：与PO同名的包作为 testPOImportPath，
+// 其余包补上 entitytool 生成的 builder 和 getter 后检查
+func assertCompiles(t *testing.T, files map[string]string) {
+	t.Helper()
+	fset := token.NewFileSet()
+	byPackage := make(map[string][]*ast.File)
+	for _, fileName := range sortedKeys(files) {
+		file, err := parser.ParseFile(fset, fileName, files[fileName], parser.ParseComments)
+		if err != nil {
+			t.Fatalf("解析生成代码失败: %v", err)
+		}
+		byPackage[file.Name.Name] = append(byPackage[file.Name.Name], file)
+	}
+	im := &sourceImporter{fset: fset, generated: make(map[string][]*ast.File), packages: make(map[string]*types.Package)}
+	var paths []string
+	for name, pkgFiles := range byPackage {
+		path := "example.com/gen/" + name
+		if name != "po" {
+			stub, err := parser.ParseFile(fset, name+"_entitytool.go", entitytoolStub(fset, im, name, pkgFiles), 0)
+			if err != nil {
+				t.Fatalf("生成 entitytool 桩代码失败: %v", err)
+			}
+			pkgFiles = append(pkgFiles, stub)
+		}
+		im.generated[path] = pkgFiles
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if _, err := im.Import(path); err != nil && len(im.errs) == 0 {
+			t.Errorf("生成代码无法编译: %v", err)
+		}
+	}
+	for _, err := range im.errs {
+		t.Errorf("生成代码无法编译: %v", err)
+	}
+}
+
+// entitytoolStub 模拟 entitytool 为 entity 结构体生成的 builder 和 getter
+func entitytoolStub(fset *token.FileSet, im *sourceImporter, pkg string, files []*ast.File) string {
+	var body strings.Builder
+	imports := make(map[string]string)
+	for _, file := range files {
+		for _, spec := range file.Imports {
+			path := strings.Trim(spec.Path.Value, `"`)
+			name := importName(path)
+			if spec.Name != nil {
+				name = spec.Name.Name
+			} else if !strings.HasPrefix(path, "example.com/gen/") {
+				// 包名不一定与路径的最后一段相同，如 go-geom 的包名为 geom
+				if imported, err := im.Import(path); err == nil {
+					name = imported.Name()
+				}
+			}
+			imports[name] = path
+		}
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE || gen.Doc == nil || !strings.Contains(gen.Doc.Text(), "entity结构体") {
+				continue
+			}
+			spec := gen.Specs[0].(*ast.TypeSpec)
+			st, ok := spec.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			name := spec.Name.Name
+			body.WriteString(fmt.Sprintf("type %sBuilder struct{ e %s }\n\n", name, name))
+			body.WriteString(fmt.Sprintf("func New%sBuilder() *%sBuilder { return &%sBuilder{} }\n\n", name, name, name))
+			body.WriteString(fmt.Sprintf("func (b *%sBuilder) Build() (*%s, error) { return &b.e, nil }\n\n", name, name))
+			for _, field := range st.Fields.List {
+				var typ bytes.Buffer
+				if err := printer.Fprint(&typ, fset, field.Type); err != nil {
+					panic(err)
+				}
+				for _, ident := range field.Names {
+					method := string(unicode.ToUpper(rune(ident.Name[0]))) + ident.Name[1:]
+					body.WriteString(fmt.Sprintf("func (b *%sBuilder) With%s(v %s) *%sBuilder { b.e.%s = v; return b }\n\n",
+						name, method, typ.String(), name, ident.Name))
+					body.WriteString(fmt.Sprintf("func (e *%s) %s() %s { return e.%s }\n\n", name, method, typ.String(), ident.Name))
+				}
+			}
+		}
+	}
+	// 只导入字段类型用到的包
+	var header strings.Builder
+	header.WriteString("package " + pkg + "\n\n")
+	used := make(map[string]bool)
+	for _, name := range referencedPackages(body.String()) {
+		if path, ok := imports[name]; ok && !used[name] {
+			used[name] = true
+			header.WriteString(fmt.Sprintf("import %s %q\n", name, path))
+		}
+	}
+	return header.String() + "\n" + body.String()
+}
+
+func TestGenerateGolden(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		args []string
+	}{
+		{
+			name: "basic",
+			sql:  "basic.sql",
+			args: []string{"--po", "User", "--entity", "UserEntity"},
+		},
+		{
+			name: "multi_table",
+			sql:  "multi_table.sql",
+			args: []string{"--po", "Unused", "--entity", "Unused"},
+		},
+		{
+			name: "multi_table_result",
+			sql:  "multi_table.sql",
+			args: []string{"--po", "Unused", "--entity", "Unused", "--result", "UserOrder:users.id,users.name,orders.amount,orders.name"},
+		},
+		{
+			name: "multi_table_result_per_table",
+			sql:  "multi_table.sql",
+			args: []string{"--po", "Unused", "--entity", "Unused", "--output-mode", "per-table",
+				"--result", "UserOrder:users.id,users.name,orders.amount,orders.name"},
+		},
+		{
+			name: "geo_nullable",
+			sql:  "geo.sql",
+			args: []string{"--po", "Place", "--entity", "PlaceEntity", "--geo-type", "github.com/twpayne/go-geom",
+				"--gen-values", "--gen-json-marshal"},
+		},
+		{
+			name: "preset_gorm",
+			sql:  "gorm.sql",
+			args: []string{"--po", "Article", "--entity", "ArticleEntity", "--preset", "gorm"},
+		},
+		{
+			name: "preset_gorm_signed_id",
+			sql:  "gorm_signed.sql",
+			args: []string{"--po", "Article", "--entity", "ArticleEntity", "--preset", "gorm"},
+		},
+		{
+			name: "repo_impl_keyword_pk",
+			sql:  "keyword_pk.sql",
+			args: []string{"--po", "Kind", "--entity", "KindEntity", "--gen-repo-impl"},
+		},
+		{
+			name: "faker_pointer",
+			sql:  "faker.sql",
+			args: []string{"--po", "Visit", "--entity", "VisitEntity", "--gen-faker", "--nullable-style", "pointer"},
+		},
+		{
+			name: "faker_pgx",
+			sql:  "faker_pg.sql",
+			args: []string{"--po", "Visit", "--entity", "VisitEntity", "--gen-faker", "--dialect", "postgres", "--driver", "pgx"},
+		},
+		{
+			name: "dialect_cockroach",
+			sql:  "cockroach.sql",
+			args: []string{"--po", "Account", "--entity", "AccountEntity", "--dialect", "cockroach"},
+		},
+		{
+			name: "dialect_duckdb",
+			sql:  "duckdb.sql",
+			args: []string{"--po", "Event", "--entity", "EventEntity", "--dialect", "duckdb"},
+		},
+		{
+			name: "comment_max_width",
+			sql:  "comment.sql",
+			args: []string{"--po", "Note", "--entity", "NoteEntity", "--comment-max-width", "60"},
+		},
+		{
+			name: "deepcopy_pq_array",
+			sql:  "deepcopy_pg.sql",
+			args: []string{"--po", "Document", "--entity", "DocumentEntity", "--gen-deepcopy",
+				"--dialect", "postgres", "--array-type", "pq", "--nullable-style", "pointer"},
+		},
+		{
+			name: "deepcopy_big_rat",
+			sql:  "deepcopy_bq.sql",
+			args: []string{"--po", "Ledger", "--entity", "LedgerEntity", "--gen-deepcopy", "--dialect", "bigquery"},
+		},
+		{
+			name: "required_from_notnull",
+			sql:  "required.sql",
+			args: []string{"--po", "Task", "--entity", "TaskEntity"},
+		},
+		{
+			name: "required_from_nodefault",
+			sql:  "required.sql",
+			args: []string{"--po", "Task", "--entity", "TaskEntity", "--required-from", "nodefault"},
+		},
+		{
+			name: "proto_tags_exclude",
+			sql:  "basic.sql",
+			args: []string{"--po", "User", "--entity", "UserEntity", "--proto-tags", "--exclude-columns", "nick,age"},
+		},
+		{
+			name: "composite_pk",
+			sql:  "composite_pk.sql",
+			args: []string{"--po", "Member", "--entity", "MemberEntity", "--gen-repo-impl"},
+		},
+		{
+			name: "pk_from_unique",
+			sql:  "unique_pk.sql",
+			args: []string{"--po", "Device", "--entity", "DeviceEntity", "--pk-from-unique", "--gen-repo-impl"},
+		},
+		{
+			name: "templates_dir",
+			sql:  "multi_table.sql",
+			args: []string{"--po", "Unused", "--entity", "Unused", "--templates-dir", filepath.Join("testdata", "templates")},
+		},
+		{
+			name: "partition",
+			sql:  "partition.sql",
+			args: []string{"--po", "Unused", "--entity", "Unused"},
+		},
+		{
+			name: "temporal",
+			sql:  "temporal.sql",
+			args: []string{"--po", "Schedule", "--entity", "ScheduleEntity"},
+		},
+		{
+			name: "bool",
+			sql:  "bool.sql",
+			args: []string{"--po", "Flag", "--entity", "FlagEntity"},
+		},
+		{
+			name: "unsigned",
+			sql:  "unsigned.sql",
+			args: []string{"--po", "Counter", "--entity", "CounterEntity"},
+		},
+		{
+			name: "json_tags",
+			sql:  "basic.sql",
+			args: []string{"--po", "User", "--entity", "UserEntity", "--json-tags"},
+		},
+		{
+			name: "dialect_postgres",
+			sql:  "postgres.sql",
+			args: []string{"--po", "Account", "--entity", "AccountEntity", "--dialect", "postgres"},
+		},
+		{
+			name: "enum_consts",
+			sql:  "enum.sql",
+			args: []string{"--po", "Account", "--entity", "AccountEntity", "--gen-enum-consts"},
+		},
+		{
+			name: "package_names",
+			sql:  "basic.sql",
+			args: []string{"--po", "User", "--entity", "UserEntity", "--package", "model", "--entity-package", "domain",
+				"--module-path", "example.com/gen/model"},
+		},
+		{
+			name: "type_map",
+			sql:  "basic.sql",
+			args: []string{"--po", "User", "--entity", "UserEntity", "--type-map", filepath.Join("testdata", "typemap", "apd.json")},
+		},
+		{
+			name: "dialect_sqlite",
+			sql:  "sqlite.sql",
+			args: []string{"--po", "Note", "--entity", "NoteEntity", "--dialect", "sqlite"},
+		},
+		{
+			name: "binary",
+			sql:  "binary.sql",
+			args: []string{"--po", "File", "--entity", "FileEntity"},
+		},
+		{
+			name: "check_between",
+			sql:  "check.sql",
+			args: []string{"--po", "Person", "--entity", "PersonEntity", "--dialect", "mysql8"},
+		},
+		{
+			name: "dialect_bigquery",
+			sql:  "bigquery.sql",
+			args: []string{"--po", "Event", "--entity", "EventEntity", "--dialect", "bigquery"},
+		},
+		{
+			name: "dialect_snowflake",
+			sql:  "snowflake.sql",
+			args: []string{"--po", "Order", "--entity", "OrderEntity", "--dialect", "snowflake"},
+		},
+		{
+			name: "entity_only_fields",
+			sql:  "entity_only.sql",
+			args: []string{"--po", "Shipment", "--entity", "ShipmentEntity",
+				"--entity-only-fields", "Type:string", "--entity-only-fields", "ID:int64", "--entity-only-fields", "trackingURL:string"},
+		},
+		{
+			name: "table_pk_implicit_nullable",
+			sql:  "table_pk_pg.sql",
+			args: []string{"--po", "Membership", "--entity", "MembershipEntity", "--dialect", "postgres", "--nullable-style", "pointer"},
+		},
+		{
+			name: "hashkey_composite",
+			sql:  "composite_pk.sql",
+			args: []string{"--po", "Member", "--entity", "MemberEntity", "--gen-hashkey"},
+		},
+		{
+			name: "hashkey_time",
+			sql:  "partition.sql",
+			args: []string{"--po", "Unused", "--entity", "Unused", "--gen-hashkey"},
+		},
+		{
+			name: "mixed_comment",
+			sql:  "mixed_comment.sql",
+			args: []string{"--po", "Customer", "--entity", "CustomerEntity"},
+		},
+		{
+			name: "nullable_pointer",
+			sql:  "basic.sql",
+			args: []string{"--po", "User", "--entity", "UserEntity", "--nullable-style", "pointer"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, err := os.ReadFile(filepath.Join("testdata", tt.sql))
+			if err != nil {
+				t.Fatal(err)
+			}
+			files := runGenerate(t, string(sql), tt.args...)
+			assertGolden(t, tt.name, files)
+			assertGofmt(t, files)
+			assertCompiles(t, files)
+		})
+	}
+}
+
+// benchmarkGenerate 解析 sql 并生成到临时目录，concurrency 为并行生成的协程数
+func benchmarkGenerate(b *testing.B, sql string, concurrency int) {
+	b.Helper()
+	dir := b.TempDir()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p := NewSQLParser("Unused", "Unused")
+		p.Concurrency = concurrency
+		p.POImportPath = testPOImportPath
+		if err := p.Parse(sql); err != nil {
+			b.Fatal(err)
+		}
+		if _, err := p.GenerateStruct(dir); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGenerateHugeSchema(b *testing.B) {
+	benchmarkGenerate(b, benchmarkSchema(2000, 10), 1)
+}
+
+func BenchmarkGenerateConcurrency(b *testing.B) {
+	sql := benchmarkSchema(500, 10)
+	for _, concurrency := range []int{1, 4, 8} {
+		b.Run(fmt.Sprintf("concurrency=%d", concurrency), func(b *testing.B) {
+			benchmarkGenerate(b, sql, concurrency)
+		})
+	}
+}
+
+func TestGenerateConcurrencyDeterministic(t *testing.T) {
+	sql := benchmarkSchema(20, 3)
+	want := runGenerate(t, sql, "--po", "Unused", "--entity", "Unused")
+	got := runGenerate(t, sql, "--po", "Unused", "--entity", "Unused", "--concurrency", "8")
+	for _, fileName := range sortedKeys(want) {
+		if got[fileName] != want[fileName] {
+			t.Errorf("并行生成的 %s 与顺序生成不一致", fileName)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("并行生成了 %d 个文件，期望 %d 个", len(got), len(want))
+	}
+}
+
+func TestGenerateImportsOnlyUsedPackages(t *testing.T) {
+	const datetimeImport = `"git.woa.com/prd_base_pay_go/paycomm/datetime"`
+	tests := []struct {
+		name, sql    string
+		wantDatetime bool
+	}{
+		{"without_datetime", "CREATE TABLE `t_tag` (\n  `id` INT NOT NULL COMMENT 'ID',\n  `name` VARCHAR(32) NOT NULL COMMENT '名称'\n);", false},
+		{"with_datetime", "CREATE TABLE `t_tag` (\n  `id` INT NOT NULL COMMENT 'ID',\n  `created_at` DATETIME NOT NULL COMMENT '创建时间'\n);", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := runGenerate(t, tt.sql, "--po", "Tag", "--entity", "TagEntity")
+			for _, fileName := range sortedKeys(files) {
+				if got := strings.Contains(files[fileName], datetimeImport); got != tt.wantDatetime {
+					t.Errorf("%s 导入 datetime 为 %t，期望 %t", fileName, got, tt.wantDatetime)
+				}
+				if strings.Contains(files[fileName], `"database/sql"`) {
+					t.Errorf("%s 没有可空列，不应导入 database/sql", fileName)
+				}
+			}
+			assertCompiles(t, files)
+		})
+	}
+}
+
+func TestOutputModeFiles(t *testing.T) {
+	sql, err := os.ReadFile(filepath.Join("testdata", "multi_table.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		mode string
+		want []string
+	}{
+		{"single", []string{"unused_entity_template.go", "unused_po_template.go"}},
+		{"per-table", []string{
+			"orders_entity_template.go", "orders_po_template.go",
+			"t_tags_entity_template.go", "t_tags_po_template.go",
+			"users_entity_template.go", "users_po_template.go",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			files := runGenerate(t, string(sql), "--po", "Unused", "--entity", "Unused", "--output-mode", tt.mode)
+			if got := sortedKeys(files); strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("生成文件为 %v，期望 %v", got, tt.want)
+			}
+			assertCompiles(t, files)
+		})
+	}
+
+	argv := []string{"sql2struct", "--sql", filepath.Join("testdata", "multi_table.sql"), "--output", t.TempDir(),
+		"--po", "Unused", "--entity", "Unused", "--output-mode", "split"}
+	if err := newApp().Run(argv); err == nil || !strings.Contains(err.Error(), "--output-mode") {
+		t.Errorf("不支持的 --output-mode 返回 %v，期望报错", err)
+	}
+}
+
+func TestEncryptMarkers(t *testing.T) {
+	sql := "CREATE TABLE `t_user` (\n" +
+		"  `token` TEXT NULL COMMENT 'encrypted token',\n" +
+		"  `phone` TEXT NULL COMMENT '手机号（敏感）',\n" +
+		"  `legacy` TEXT NULL COMMENT '加密字段',\n" +
+		"  `memo` TEXT NULL COMMENT '备注'\n" +
+		");"
+	files := runGenerate(t, sql, "--po", "User", "--entity", "UserEntity", "--encrypt-marker", "encrypted, 敏感")
+	po := files["user_entity_po_template.go"]
+	tests := []struct {
+		column    string
+		omitempty bool
+	}{
+		{"token", true},
+		{"phone", true},
+		{"legacy", false},
+		{"memo", false},
+	}
+	for _, tt := range tests {
+		tag := fmt.Sprintf(`db:"%s" validate:"omitempty"`, tt.column)
+		if got := strings.Contains(po, tag); got != tt.omitempty {
+			t.Errorf("%s 生成 omitempty 为 %t，期望 %t:\n%s", tt.column, got, tt.omitempty, po)
+		}
+	}
+}
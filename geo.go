@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// goGeomModule --geo-type 目前支持的空间库
+const goGeomModule = "github.com/twpayne/go-geom"
+
+// spatialTypes MySQL 的空间类型，默认映射为 []byte
+var spatialTypes = []string{
+	"GEOMETRY", "POINT", "LINESTRING", "POLYGON",
+	"MULTIPOINT", "MULTILINESTRING", "MULTIPOLYGON", "GEOMETRYCOLLECTION", "GEOMCOLLECTION",
+}
+
+// geometryType 指定 --geo-type 后空间列使用的类型，按需生成到PO文件中
+const geometryType = "Geometry"
+
+// geometryDef Geometry 的定义，按 MySQL 的存储格式（4字节小端 SRID 加 WKB）读写 go-geom 的几何对象
+const geometryDef = `// Geometry MySQL 空间列，T 为 nil 表示 NULL
+type Geometry struct {
+	geom.T
+}
+
+// Scan 实现 sql.Scanner
+func (g *Geometry) Scan(value interface{}) error {
+	if value == nil {
+		g.T = nil
+		return nil
+	}
+	b, ok := value.([]byte)
+	if !ok || len(b) < 4 {
+		return fmt.Errorf("Geometry: 不支持的类型 %T", value)
+	}
+	t, err := wkb.Unmarshal(b[4:])
+	if err != nil {
+		return err
+	}
+	srid := int(binary.LittleEndian.Uint32(b[:4]))
+	switch t := t.(type) {
+	case *geom.Point:
+		t.SetSRID(srid)
+	case *geom.LineString:
+		t.SetSRID(srid)
+	case *geom.Polygon:
+		t.SetSRID(srid)
+	case *geom.MultiPoint:
+		t.SetSRID(srid)
+	case *geom.MultiLineString:
+		t.SetSRID(srid)
+	case *geom.MultiPolygon:
+		t.SetSRID(srid)
+	case *geom.GeometryCollection:
+		t.SetSRID(srid)
+	}
+	g.T = t
+	return nil
+}
+
+// Value 实现 driver.Valuer
+func (g Geometry) Value() (driver.Value, error) {
+	if g.T == nil {
+		return nil, nil
+	}
+	data, err := wkb.Marshal(g.T, wkb.NDR)
+	if err != nil {
+		return nil, err
+	}
+	srid := make([]byte, 4)
+	binary.LittleEndian.PutUint32(srid, uint32(g.T.SRID()))
+	return append(srid, data...), nil
+}
+`
+
+// SetGeoType 让空间列使用 geoType 对应库的类型，可空与非空列都映射为 Geometry
+func (p *SQLParser) SetGeoType(geoType string) error {
+	if strings.TrimSuffix(strings.TrimSuffix(geoType, "..."), "/") != goGeomModule {
+		return fmt.Errorf("不支持的 --geo-type 取值: %s", geoType)
+	}
+	for _, sqlType := range spatialTypes {
+		p.TypeMappings[sqlType] = geometryType
+		p.NullableTypeMappings[sqlType] = geometryType
+	}
+	return nil
+}
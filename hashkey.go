@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// writeHashKey 生成把主键值拼接为字符串的 HashKey 方法，便于在内存中按主键建立 map 索引；
+// 联合主键各列之间以 \x00 分隔，时间类型使用 UnixNano 避免单调时钟读数影响结果。
+// 可空主键（如 --pk-from-unique 选中的可空唯一列）的 NULL 无法与零值区分，指针还会输出地址，因此跳过生成
+func (p *SQLParser) writeHashKey(builder *strings.Builder) {
+	var verbs, values []string
+	for _, field := range p.Fields {
+		if !field.IsPrimaryKey || field.Ignored {
+			continue
+		}
+		if field.Nullable || strings.HasPrefix(field.FieldType, "*") {
+			p.Warnings = append(p.Warnings, fmt.Sprintf("表 %s 的主键列 %s 可为空，跳过 HashKey 生成", p.TableName, field.OriginalField))
+			return
+		}
+		value := "p." + field.FieldName + valueAccessor(field.FieldType)
+		if basicType(field.FieldType) == "time.Time" {
+			value += ".UnixNano()"
+		}
+		verbs = append(verbs, "%v")
+		values = append(values, value)
+	}
+	if len(values) == 0 {
+		p.Warnings = append(p.Warnings, fmt.Sprintf("表 %s 没有主键，跳过 HashKey 生成", p.TableName))
+		return
+	}
+	builder.WriteString("// HashKey 返回由主键值拼接成的字符串，可用作 map 的键\n")
+	builder.WriteString(fmt.Sprintf("func (p %s) HashKey() string {\n", p.StructName))
+	if len(values) == 1 {
+		builder.WriteString(fmt.Sprintf("\treturn fmt.Sprint(%s)\n}\n\n", values[0]))
+		return
+	}
+	builder.WriteString(fmt.Sprintf("\treturn fmt.Sprintf(%q, %s)\n}\n\n",
+		strings.Join(verbs, "\x00"), strings.Join(values, ", ")))
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// timeToNullDateTimeFunc PO与entity转换时使用的可空时间转换函数
+const timeToNullDateTimeFunc = `// TimeToNullDateTime Time 转成 datetime.NullDateTime
+func TimeToNullDateTime(t time.Time) datetime.NullDateTime {
+	if !t.IsZero() {
+		return datetime.NullDateTime{Time: datetime.NewDateTime(t), Valid: true}
+	}
+	return datetime.NullDateTime{Valid: false}
+}
+`
+
+// pointerFuncs PO字段为基础类型的指针时，PO与entity转换使用的函数
+const pointerFuncs = `// DerefOrZero 返回指针指向的值，nil 时返回零值
+func DerefOrZero[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
+
+// PtrOf 返回值的指针
+func PtrOf[T any](v T) *T {
+	return &v
+}
+`
+
+// helpersQualifier 返回引用转换函数时的包名前缀，未使用共享文件时为空
+func (p *SQLParser) helpersQualifier() string {
+	if p.NullableHelpersDir == "" {
+		return ""
+	}
+	return filepath.Base(p.NullableHelpersDir) + "."
+}
+
+// GenerateNullableHelpers 把可空类型转换函数写入共享目录的 nullhelpers.go，
+// 避免多个表生成到同一个包时重复定义
+func (p *SQLParser) GenerateNullableHelpers() (string, error) {
+	if err := os.MkdirAll(p.NullableHelpersDir, 0755); err != nil {
+		return "", fmt.Errorf("创建转换函数目录失败: %w", err)
+	}
+	fileName := filepath.Join(p.NullableHelpersDir, "nullhelpers.go")
+	content := fmt.Sprintf("package %s\n\nimport (\n\t\"time\"\n\n\t\"git.woa.com/prd_base_pay_go/paycomm/datetime\"\n)\n\n%s\n%s",
+		filepath.Base(p.NullableHelpersDir), timeToNullDateTimeFunc, pointerFuncs)
+	if err := os.WriteFile(fileName, []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("写入文件失败: %w", err)
+	}
+	return fileName, nil
+}
+
+// nullUint64Type 可空 BIGINT UNSIGNED 列使用的类型，sql.NullInt64 无法容纳超过 MaxInt64 的值
+const nullUint64Type = "NullUint64"
+
+// nullUint64Def NullUint64 的定义，按需生成到PO文件中
+const nullUint64Def = `// NullUint64 可为 NULL 的 uint64，用于 BIGINT UNSIGNED 列
+type NullUint64 struct {
+	Uint64 uint64
+	Valid  bool
+}
+
+// Scan 实现 sql.Scanner
+func (n *NullUint64) Scan(value interface{}) error {
+	if value == nil {
+		n.Uint64, n.Valid = 0, false
+		return nil
+	}
+	n.Valid = true
+	switch v := value.(type) {
+	case int64:
+		n.Uint64 = uint64(v)
+		return nil
+	case []byte:
+		u, err := strconv.ParseUint(string(v), 10, 64)
+		n.Uint64 = u
+		return err
+	case string:
+		u, err := strconv.ParseUint(v, 10, 64)
+		n.Uint64 = u
+		return err
+	}
+	return fmt.Errorf("NullUint64: 不支持的类型 %T", value)
+}
+
+// Value 实现 driver.Valuer
+func (n NullUint64) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return strconv.FormatUint(n.Uint64, 10), nil
+}
+
+`
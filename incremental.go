@@ -0,0 +1,150 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+// incrementalCacheFile 记录上次生成时输入摘要的缓存文件，位于输出目录下
+const incrementalCacheFile = ".sql2struct.cache"
+
+// cacheEntry 一次生成中各表的输入摘要及生成的文件
+type cacheEntry struct {
+	// Tables 按小写表名记录
+	Tables map[string]tableCacheEntry `json:"tables"`
+	// Files 不属于单张表的文件，如单文件输出、TypeScript 和转换函数文件
+	Files []string `json:"files"`
+}
+
+// tableCacheEntry 一张表的输入摘要及只属于该表的文件
+type tableCacheEntry struct {
+	Hash  string   `json:"hash"`
+	Files []string `json:"files"`
+}
+
+// generationCache 以 entity 结构体名区分同一输出目录下的多次生成
+type generationCache struct {
+	path    string
+	Entries map[string]cacheEntry `json:"entries"`
+}
+
+// loadGenerationCache 读取输出目录下的缓存，不存在或损坏时视为空缓存
+func loadGenerationCache(outputDir string) *generationCache {
+	cache := &generationCache{path: filepath.Join(outputDir, incrementalCacheFile)}
+	if content, err := os.ReadFile(cache.path); err == nil {
+		_ = json.Unmarshal(content, cache)
+	}
+	if cache.Entries == nil {
+		cache.Entries = make(map[string]cacheEntry)
+	}
+	return cache
+}
+
+// unchangedTables 返回输入摘要与上次相同且上次生成的文件都还在的表；
+// 共用的文件缺失时所有表都需要重新生成
+func (g *generationCache) unchangedTables(key string, hashes map[string]string) map[string]bool {
+	unchanged := make(map[string]bool)
+	entry, ok := g.Entries[key]
+	if !ok || !filesExist(entry.Files) {
+		return unchanged
+	}
+	for table, hash := range hashes {
+		if cached, ok := entry.Tables[table]; ok && cached.Hash == hash && filesExist(cached.Files) {
+			unchanged[table] = true
+		}
+	}
+	return unchanged
+}
+
+// filesExist 判断文件是否都存在
+func filesExist(files []string) bool {
+	for _, file := range files {
+		if _, err := os.Stat(file); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// save 记录本次生成的各表输入摘要和文件并写回缓存文件。tableFiles 为本次生成的各表文件，
+// 跳过生成的表沿用上次记录的文件；files 中不属于任何表的文件记为共用文件
+func (g *generationCache) save(key string, hashes map[string]string, tableFiles map[string][]string, files []string) error {
+	previous := g.Entries[key]
+	entry := cacheEntry{Tables: make(map[string]tableCacheEntry, len(hashes))}
+	owned := make(map[string]bool)
+	for table, hash := range hashes {
+		written, ok := tableFiles[table]
+		if !ok {
+			written = previous.Tables[table].Files
+		}
+		entry.Tables[table] = tableCacheEntry{Hash: hash, Files: written}
+		for _, file := range written {
+			owned[file] = true
+		}
+	}
+	for _, file := range files {
+		if !owned[file] {
+			entry.Files = append(entry.Files, file)
+		}
+	}
+	g.Entries[key] = entry
+	content, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(g.path, content, 0644); err != nil {
+		return fmt.Errorf("写入缓存文件失败: %w", err)
+	}
+	return nil
+}
+
+// tableHashes 按小写表名计算各表的输入摘要，由该表的建表语句和所有表共同的 optionsHash 组成
+func (p *SQLParser) tableHashes(optionsHash string) map[string]string {
+	tables := p.Tables
+	if len(tables) == 0 {
+		tables = []*SQLParser{p}
+	}
+	hashes := make(map[string]string, len(tables))
+	for _, table := range tables {
+		sum := sha256.Sum256([]byte(optionsHash + "\x00" + table.Source))
+		hashes[strings.ToLower(table.TableName)] = hex.EncodeToString(sum[:])
+	}
+	return hashes
+}
+
+// inputHash 计算显式设置的参数以及类型映射文件、模板的摘要，任何一项变化都会重新生成所有表
+func inputHash(c *cli.Context) (string, error) {
+	h := sha256.New()
+	for _, name := range c.FlagNames() {
+		fmt.Fprintf(h, "\x00%s=%v", name, c.Value(name))
+	}
+	var files []string
+	if typeMap := c.String("type-map"); typeMap != "" {
+		files = append(files, typeMap)
+	}
+	if templatesDir := c.String("templates-dir"); templatesDir != "" {
+		templates, err := filepath.Glob(filepath.Join(templatesDir, "*.tmpl"))
+		if err != nil {
+			return "", fmt.Errorf("查找模板失败: %w", err)
+		}
+		sort.Strings(templates)
+		files = append(files, templates...)
+	}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("读取文件失败: %w", err)
+		}
+		fmt.Fprintf(h, "\x00%s\x00", file)
+		h.Write(content)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIncrementalSkipsUnchangedTables(t *testing.T) {
+	dir := t.TempDir()
+	sqlFile := filepath.Join(dir, "schema.sql")
+	outputDir := filepath.Join(dir, "out")
+	schema, err := os.ReadFile(filepath.Join("testdata", "multi_table.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	generate := func(sql string) {
+		t.Helper()
+		if err := os.WriteFile(sqlFile, []byte(sql), 0644); err != nil {
+			t.Fatal(err)
+		}
+		argv := []string{"sql2struct", "--sql", sqlFile, "--output", outputDir, "--module-path", testPOImportPath,
+			"--po", "Unused", "--entity", "Unused", "--output-mode", "per-table", "--incremental"}
+		if err := newApp().Run(argv); err != nil {
+			t.Fatalf("生成失败: %v", err)
+		}
+	}
+	// 用标记内容覆盖生成的文件，重新生成后仍为标记说明该文件被跳过
+	const marker = "// unchanged\n"
+	mark := func(files ...string) {
+		t.Helper()
+		for _, file := range files {
+			if err := os.WriteFile(filepath.Join(outputDir, file), []byte(marker), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	skipped := func(file string) bool {
+		t.Helper()
+		content, err := os.ReadFile(filepath.Join(outputDir, file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(content) == marker
+	}
+
+	generate(string(schema))
+	mark("users_po_template.go", "orders_po_template.go", "t_tags_po_template.go")
+	generate(string(schema))
+	for _, file := range []string{"users_po_template.go", "orders_po_template.go", "t_tags_po_template.go"} {
+		if !skipped(file) {
+			t.Errorf("输入未变化时不应重新生成 %s", file)
+		}
+	}
+
+	// 只修改 t_tags 表：它和保存共享定义的第一张表重新生成，orders 保持不变
+	generate(strings.Replace(string(schema), "`tag` VARCHAR(16)", "`tag` VARCHAR(32)", 1))
+	if skipped("t_tags_po_template.go") {
+		t.Error("t_tags 的建表语句变化后应重新生成")
+	}
+	if skipped("users_po_template.go") {
+		t.Error("第一张表的文件包含共享定义，有表变化时应重新生成")
+	}
+	if !skipped("orders_po_template.go") {
+		t.Error("orders 的建表语句未变化，不应重新生成")
+	}
+}
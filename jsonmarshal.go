@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// writeJSONMarshal 生成 MarshalJSON，把 sql.Null* 等可空字段编码为取值或 null，
+// 而不是 {"String":"x","Valid":true}
+func (p *SQLParser) writeJSONMarshal(builder *strings.Builder) {
+	var nullable []FieldMeta
+	for _, field := range p.Fields {
+		if !field.Ignored && field.Nullable && valueAccessor(field.FieldType) != "" {
+			nullable = append(nullable, field)
+		}
+	}
+	if len(nullable) == 0 {
+		return
+	}
+
+	builder.WriteString("// MarshalJSON 可空字段编码为取值，无效时编码为 null\n")
+	builder.WriteString(fmt.Sprintf("func (p %s) MarshalJSON() ([]byte, error) {\n", p.StructName))
+	builder.WriteString(fmt.Sprintf("\ttype alias %s\n", p.StructName))
+	builder.WriteString("\taux := struct {\n\t\talias\n")
+	for _, field := range nullable {
+		builder.WriteString(fmt.Sprintf("\t\t%s *%s `json:\"%s\"`\n",
+			field.FieldName, basicType(field.FieldType), p.jsonName(field)))
+	}
+	builder.WriteString("\t}{alias: alias(p)}\n")
+	for _, field := range nullable {
+		builder.WriteString(fmt.Sprintf("\tif %s {\n", validCondition(field)))
+		builder.WriteString(fmt.Sprintf("\t\tv := p.%s%s\n", field.FieldName, valueAccessor(field.FieldType)))
+		builder.WriteString(fmt.Sprintf("\t\taux.%s = &v\n\t}\n", field.FieldName))
+	}
+	builder.WriteString("\treturn json.Marshal(aux)\n}\n\n")
+}
+
+// jsonName 返回字段序列化后的JSON键，与 fieldTag 生成的 json 标签保持一致
+func (p *SQLParser) jsonName(field FieldMeta) string {
+	if !p.JSONTags {
+		return field.FieldName
+	}
+	if field.Nullable && !field.IsPrimaryKey {
+		return field.OriginalField + ",omitempty"
+	}
+	return field.OriginalField
+}
@@ -1,38 +1,61 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"go/token"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
+	"text/tabwriter"
+	"text/template"
 	"unicode"
 
 	"github.com/urfave/cli/v2"
 )
 
 func main() {
-	app := &cli.App{
+	if err := newApp().Run(os.Args); err != nil {
+		fmt.Fprintf(os.Stderr, "错误: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+}
+
+// newApp 创建命令行应用，测试中可以直接以参数调用
+func newApp() *cli.App {
+	return &cli.App{
 		Name:  "sql2struct",
 		Usage: "Generate Go structs from SQL schema",
+		Description: "Exit codes: 0 success, 1 parse or IO error, " +
+			"2 unmapped column types with --strict, 3 columns mapping to the same field name",
 		Flags: []cli.Flag{
 			&cli.StringFlag{
-				Name:     "sql",
-				Aliases:  []string{"s"},
-				Usage:    "Path to SQL schema file",
-				Required: true,
+				Name:    "sql",
+				Aliases: []string{"s"},
+				Usage:   "Path to SQL schema file, or - to read from stdin",
+			},
+			&cli.StringFlag{
+				Name:    "po",
+				Aliases: []string{"p"},
+				Usage:   "Name for PO struct",
+			},
+			&cli.StringFlag{
+				Name:    "entity",
+				Aliases: []string{"e"},
+				Usage:   "Name for Entity struct",
 			},
 			&cli.StringFlag{
-				Name:     "po",
-				Aliases:  []string{"p"},
-				Usage:    "Name for PO struct",
-				Required: true,
+				Name:  "package",
+				Usage: "Package name of the generated PO file",
+				Value: "po",
 			},
 			&cli.StringFlag{
-				Name:     "entity",
-				Aliases:  []string{"e"},
-				Usage:    "Name for Entity struct",
-				Required: true,
+				Name:  "entity-package",
+				Usage: "Package name of the generated entity file",
+				Value: "entity",
 			},
 			&cli.StringFlag{
 				Name:    "output",
@@ -40,90 +63,882 @@ func main() {
 				Usage:   "Output directory",
 				Value:   ".",
 			},
+			&cli.StringFlag{
+				Name:  "output-suffix",
+				Usage: "Suffix appended to generated file names, e.g. _gen for users_po_gen.go and users_entity_gen.go",
+				Value: defaultOutputSuffix,
+			},
+			&cli.StringFlag{
+				Name:  "output-mode",
+				Usage: "single writes all tables into one PO and one entity file; per-table writes each table to files named after the table",
+				Value: "single",
+			},
+			&cli.BoolFlag{
+				Name:  "time-utc",
+				Usage: "Normalize time columns to UTC in conversions and Values(), noted in field comments",
+			},
+			&cli.StringFlag{
+				Name:  "preset",
+				Usage: "Apply a bundle of defaults: sqlx, gorm or ent; explicit flags still win",
+			},
+			&cli.StringFlag{
+				Name:  "orm",
+				Usage: "Tag style of PO fields: empty for db tags, gorm for gorm:\"column:...;type:...\" tags",
+			},
+			&cli.BoolFlag{
+				Name:  "gorm-model",
+				Usage: "Embed gorm.Model in tables whose id, created_at, updated_at and deleted_at columns have compatible types",
+			},
+			&cli.BoolFlag{
+				Name:  "proto-tags",
+				Usage: "Emit gogo/protobuf style protobuf:\"...\" tags numbered by column ordinal",
+			},
+			&cli.StringFlag{
+				Name:  "struct-doc-template",
+				Usage: "text/template for the PO struct doc comment (fields: .Struct, .Table, .TableComment)",
+			},
+			&cli.BoolFlag{
+				Name:  "json-tags",
+				Usage: "Emit json tags (nullable non-PK columns get omitempty)",
+			},
+			&cli.BoolFlag{
+				Name:  "bigint-pk-json-string",
+				Usage: "Serialize BIGINT primary keys as JSON strings",
+			},
+			&cli.StringFlag{
+				Name:  "db-omitempty",
+				Usage: "Append omitempty to db tags; supported value: nullable",
+			},
+			&cli.StringFlag{
+				Name:  "ts-output",
+				Usage: "Also write a TypeScript interface to the given file",
+			},
+			&cli.BoolFlag{
+				Name:  "incremental",
+				Usage: "Skip tables whose DDL, flags, type map and templates are unchanged since the last run (tracked in .sql2struct.cache); only per-table output and templates rewrite individual tables",
+			},
+			&cli.StringFlag{
+				Name:  "templates-dir",
+				Usage: "Render every *.tmpl in the directory once per table instead of the built-in output",
+			},
+			&cli.StringFlag{
+				Name:  "nullable-style",
+				Usage: "Go type for nullable columns: null (sql.NullString etc.) or pointer (*string etc.)",
+				Value: "null",
+			},
+			&cli.StringFlag{
+				Name:  "array-type",
+				Usage: "Go type for array columns such as TEXT[]: slice or pq",
+				Value: "slice",
+			},
+			&cli.BoolFlag{
+				Name:  "warn-unparsed",
+				Usage: "Warn about column definitions that could not be parsed",
+				Value: true,
+			},
+			&cli.BoolFlag{
+				Name:  "pk-from-unique",
+				Usage: "Treat the first single-column UNIQUE key as primary key when none is declared",
+			},
+			&cli.StringFlag{
+				Name:  "column-trim-prefix",
+				Usage: "Prefix trimmed from column names before deriving field names",
+			},
+			&cli.StringFlag{
+				Name:  "column-trim-suffix",
+				Usage: "Suffix trimmed from column names before deriving field names",
+			},
+			&cli.StringSliceFlag{
+				Name:  "entity-only-fields",
+				Usage: "Extra entity fields without a DB column, as name:type",
+			},
+			&cli.StringSliceFlag{
+				Name:  "exclude-columns",
+				Usage: "Columns to leave out of the generated structs",
+			},
+			&cli.BoolFlag{
+				Name:  "ignore-as-blank",
+				Usage: "Keep excluded columns as blank _ fields to preserve column positions",
+			},
+			&cli.StringFlag{
+				Name:  "dialect",
+				Usage: "SQL dialect: mysql, mysql8, mysql5, postgres, ansi, duckdb, cockroach, snowflake, bigquery, sqlite or redshift",
+				Value: "mysql",
+			},
+			&cli.BoolFlag{
+				Name:  "gen-insert-parts",
+				Usage: "Generate Columns and Placeholders for parameterized inserts",
+			},
+			&cli.BoolFlag{
+				Name:  "gen-faker",
+				Usage: "Generate a Fake<PO> factory returning random test data",
+			},
+			&cli.BoolFlag{
+				Name:  "gen-upsert",
+				Usage: "Generate a dialect-aware upsert statement keyed on the primary key",
+			},
+			&cli.BoolFlag{
+				Name:  "gen-namedargs",
+				Usage: "Generate a NamedArgs method returning sql.Named arguments",
+			},
+			&cli.BoolFlag{
+				Name:  "gen-values",
+				Usage: "Generate a Values method returning field values in column order",
+			},
+			&cli.BoolFlag{
+				Name:  "gen-scandest",
+				Usage: "Generate a scanDest method returning field pointers in column order for rows.Scan",
+			},
+			&cli.BoolFlag{
+				Name:  "gen-hashkey",
+				Usage: "Generate a HashKey method joining primary key values into a string usable as a map key",
+			},
+			&cli.BoolFlag{
+				Name:  "gen-schema-var",
+				Usage: "Generate a <Struct>Schema variable of ColumnDef describing the parsed columns for runtime schema checks",
+			},
+			&cli.BoolFlag{
+				Name:  "gen-repo-impl",
+				Usage: "Generate context-aware repository method stubs keyed on the primary key",
+			},
+			&cli.BoolFlag{
+				Name:  "gen-filter",
+				Usage: "Generate a <PO>Filter struct with a Where method building a parameterized WHERE",
+			},
+			&cli.BoolFlag{
+				Name:  "gen-deepcopy",
+				Usage: "Generate a DeepCopy method that clones slice and pointer fields",
+			},
+			&cli.StringFlag{
+				Name:    "type-map",
+				Aliases: []string{"mapping"},
+				Usage:   "JSON file overriding type mappings: {\"types\": {...}, \"nullable_types\": {...}, \"imports\": [...]}",
+			},
+			&cli.StringFlag{
+				Name:  "driver",
+				Usage: "Database driver the structs are scanned with: database/sql or pgx (nullable columns use pgtype types)",
+				Value: "database/sql",
+			},
+			&cli.StringFlag{
+				Name:  "geo-type",
+				Usage: "Map MySQL spatial columns to a spatial library with a generated WKB scanner (supported: github.com/twpayne/go-geom)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "column-type",
+				Usage: "Override the Go type of one column as col=type; precedence is dialect < --type-map < --column-type < @type: comment",
+			},
+			&cli.StringSliceFlag{
+				Name:    "field-tag",
+				Aliases: []string{"field-tag-raw"},
+				Usage:   "Append raw struct tags to one column's field as col=key:\"value\", repeatable",
+			},
+			&cli.BoolFlag{
+				Name:  "print-type-map",
+				Usage: "Print the merged type mappings and exit",
+			},
+			&cli.StringSliceFlag{
+				Name:  "result",
+				Usage: "Generate a flat struct for a join result as name:table1.col1,table2.col2",
+			},
+			&cli.BoolFlag{
+				Name:  "strip-f-prefix",
+				Usage: "Strip the F column-name prefix (Fstatus -> Status, FFlag -> Flag, f_amount -> Amount)",
+			},
+			&cli.StringFlag{
+				Name:  "strip-comment-prefix",
+				Usage: "Regex removed from the start of column comments, e.g. '\\[F\\d+\\]\\s*'",
+			},
+			&cli.StringFlag{
+				Name:  "encrypt-marker",
+				Usage: "Comma-separated keywords marking encrypted columns, which get validate:\"omitempty\"",
+				Value: defaultEncryptMarker,
+			},
+			&cli.IntFlag{
+				Name:  "concurrency",
+				Usage: "Number of tables to parse and generate in parallel",
+				Value: 1,
+			},
+			&cli.IntFlag{
+				Name:  "comment-max-width",
+				Usage: "Move field comments above the field, wrapped to this width, when a line would exceed it (0 disables)",
+			},
+			&cli.BoolFlag{
+				Name:  "strict",
+				Usage: "Fail with exit code 2 when a column type has no Go mapping",
+			},
+			&cli.BoolFlag{
+				Name:  "gen-enum-map",
+				Usage: "Generate value<->label maps for ENUM columns and comments like 0-关闭,1-开启",
+			},
+			&cli.BoolFlag{
+				Name:  "gen-json-marshal",
+				Usage: "Generate MarshalJSON encoding sql.Null* fields as their value or null",
+			},
+			&cli.BoolFlag{
+				Name:    "gen-enum-consts",
+				Aliases: []string{"enum-consts"},
+				Usage:   "Generate a named type and constants for ENUM columns and integer columns whose comment lists values",
+			},
+			&cli.StringFlag{
+				Name:  "enum-comment-regex",
+				Usage: "Regex with two groups (value, label) extracting enum values from comments",
+				Value: defaultEnumCommentPattern,
+			},
+			&cli.StringFlag{
+				Name:  "required-from",
+				Usage: "Emit validate:\"required\" for notnull columns (except zero or computed defaults) or only nodefault (NOT NULL without DEFAULT) columns",
+				Value: "notnull",
+			},
+			&cli.StringFlag{
+				Name:  "varchar-validate",
+				Usage: "VARCHAR max validation semantics: char or byte (byte emits a custom max_bytes rule)",
+				Value: "char",
+			},
+			&cli.StringFlag{
+				Name:  "module-path",
+				Usage: "Import path of the output package (detected from go.mod by default)",
+			},
+			&cli.StringFlag{
+				Name:  "entity-receiver",
+				Usage: "Receiver style for entity methods and conversion parameters: pointer or value",
+				Value: "pointer",
+			},
+			&cli.StringFlag{
+				Name:  "emit-nullable-helpers-file",
+				Usage: "Write conversion helpers once to nullhelpers.go in the given package directory",
+			},
+			&cli.BoolFlag{
+				Name:  "no-go-generate",
+				Usage: "Omit the //go:generate directive on the entity struct",
+			},
+			&cli.StringFlag{
+				Name:  "entitytool-cmd",
+				Usage: "Tool invoked by the entity //go:generate directive",
+				Value: "entitytool",
+			},
+			&cli.BoolFlag{
+				Name:  "time-precision-comment",
+				Usage: "Note fractional-second precision of DATETIME(n) columns in field comments",
+			},
+			&cli.BoolFlag{
+				Name:  "watch",
+				Usage: "Watch the SQL file and regenerate on every change",
+			},
+			&cli.StringFlag{
+				Name:  "extends",
+				Usage: "Embed the given base PO struct (for 1:1 extension tables)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "extends-columns",
+				Usage: "Columns owned by the base struct given via --extends",
+			},
+		},
+		Commands: []*cli.Command{
+			{
+				Name:  "inspect",
+				Usage: "Print the parsed schema without generating Go code",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "sql",
+						Aliases:  []string{"s"},
+						Usage:    "Path to SQL schema file, or - to read from stdin",
+						Required: true,
+					},
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Emit the parsed model as JSON",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					parser := NewSQLParser()
+					if err := loadSQL(parser, c.String("sql")); err != nil {
+						return err
+					}
+					if c.Bool("json") {
+						return parser.WriteJSON(os.Stdout)
+					}
+					return parser.WriteSummary(os.Stdout)
+				},
+			},
+			{
+				Name:  "reverse",
+				Usage: "Generate a MySQL CREATE TABLE statement from Go structs with db/gorm tags",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "go",
+						Aliases:  []string{"g"},
+						Usage:    "Path to Go source file",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  "struct",
+						Usage: "Only reverse the named struct",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					return NewSQLParser().ReverseGoFile(os.Stdout, c.String("go"), c.String("struct"))
+				},
+			},
 		},
 		Action: func(c *cli.Context) error {
-			parser := NewSQLParser(c.String("po"), c.String("entity"))
-
-			// 获取绝对路径
-			sqlPath, err := filepath.Abs(c.String("sql"))
-			if err != nil {
-				return fmt.Errorf("解析SQL文件路径失败: %w", err)
-			}
-
-			if err := parser.LoadSQLFile(sqlPath); err != nil {
+			if err := applyPreset(c); err != nil {
 				return err
 			}
+			if c.Bool("print-type-map") {
+				parser := NewSQLParser()
+				if err := configureTypeMappings(parser, c); err != nil {
+					return err
+				}
+				return parser.WriteTypeMap(os.Stdout)
+			}
 
-			// 设置输出路径
-			outputDir := c.String("output")
-			if err := os.MkdirAll(outputDir, 0755); err != nil {
-				return fmt.Errorf("创建输出目录失败: %w", err)
+			// 子命令不需要这些参数，因此在此处而不是通过 Required 校验
+			for _, name := range []string{"sql", "po", "entity"} {
+				if c.String(name) == "" {
+					return fmt.Errorf("缺少必需参数: --%s", name)
+				}
 			}
 
-			// 生成代码
-			if _, err := parser.GenerateStruct(outputDir); err != nil {
-				return err
+			if !c.Bool("watch") {
+				return generate(c)
+			}
+			if c.String("sql") == "-" {
+				return fmt.Errorf("--watch 不支持从标准输入读取SQL")
 			}
+			if err := generate(c); err != nil {
+				fmt.Fprintf(os.Stderr, "错误: %v\n", err)
+			}
+			return watch(c)
+		},
+	}
+}
+
+// configureTypeMappings 按 方言 < 类型映射文件 < 按列覆盖 的顺序设置类型映射
+func configureTypeMappings(parser *SQLParser, c *cli.Context) error {
+	dialect, err := LookupDialect(c.String("dialect"))
+	if err != nil {
+		return err
+	}
+	parser.SetDialect(dialect)
+	if err := parser.SetDriver(c.String("driver")); err != nil {
+		return err
+	}
+	if geoType := c.String("geo-type"); geoType != "" {
+		if err := parser.SetGeoType(geoType); err != nil {
+			return err
+		}
+	}
+	if path := c.String("type-map"); path != "" {
+		config, err := LoadTypeMapFile(path)
+		if err != nil {
+			return err
+		}
+		parser.ApplyTypeMap(config)
+	}
+	for _, spec := range c.StringSlice("column-type") {
+		column, goType, err := parseColumnType(spec)
+		if err != nil {
+			return err
+		}
+		if parser.ColumnTypes == nil {
+			parser.ColumnTypes = make(map[string]string)
+		}
+		parser.ColumnTypes[strings.ToLower(column)] = goType
+	}
+	return nil
+}
+
+// generate 按命令行参数解析SQL文件并生成代码
+func generate(c *cli.Context) error {
+	parser := NewSQLParser(c.String("po"), c.String("entity"))
+	if err := configureTypeMappings(parser, c); err != nil {
+		return err
+	}
+	parser.Concurrency = c.Int("concurrency")
+	if parser.Concurrency < 1 {
+		return fmt.Errorf("不支持的 --concurrency 取值: %d", parser.Concurrency)
+	}
+	parser.POPackage = c.String("package")
+	if !token.IsIdentifier(parser.POPackage) {
+		return fmt.Errorf("不支持的 --package 取值: %s", parser.POPackage)
+	}
+	parser.EntityPackage = c.String("entity-package")
+	if !token.IsIdentifier(parser.EntityPackage) {
+		return fmt.Errorf("不支持的 --entity-package 取值: %s", parser.EntityPackage)
+	}
+	parser.GenInsertParts = c.Bool("gen-insert-parts")
+	parser.GenFaker = c.Bool("gen-faker")
+	parser.GenUpsert = c.Bool("gen-upsert")
+	parser.GenNamedArgs = c.Bool("gen-namedargs")
+	parser.GenValues = c.Bool("gen-values")
+	parser.GenScanDest = c.Bool("gen-scandest")
+	parser.GenHashKey = c.Bool("gen-hashkey")
+	parser.GenSchemaVar = c.Bool("gen-schema-var")
+	parser.GenRepoImpl = c.Bool("gen-repo-impl")
+	parser.GenFilter = c.Bool("gen-filter")
+	parser.GenDeepCopy = c.Bool("gen-deepcopy")
+	parser.GenEnumMap = c.Bool("gen-enum-map")
+	parser.GenEnumConsts = c.Bool("gen-enum-consts")
+	parser.GenJSONMarshal = c.Bool("gen-json-marshal")
+	if pattern := c.String("enum-comment-regex"); pattern != "" {
+		re, err := compileEnumCommentRegex(pattern)
+		if err != nil {
+			return err
+		}
+		parser.EnumCommentRegex = re
+	}
+	parser.JSONTags = c.Bool("json-tags")
+	parser.ProtoTags = c.Bool("proto-tags")
+	parser.ORM = c.String("orm")
+	parser.TimeUTC = c.Bool("time-utc")
+	if parser.ORM != "" && parser.ORM != "gorm" {
+		return fmt.Errorf("不支持的 --orm 取值: %s", parser.ORM)
+	}
+	if doc := c.String("struct-doc-template"); doc != "" {
+		parser.StructDocTemplate = doc
+	}
+	parser.BigintPKJSONString = c.Bool("bigint-pk-json-string")
+	parser.DBOmitEmpty = c.String("db-omitempty")
+	if parser.DBOmitEmpty != "" && parser.DBOmitEmpty != "nullable" {
+		return fmt.Errorf("不支持的 --db-omitempty 取值: %s", parser.DBOmitEmpty)
+	}
+	parser.ExtendsStruct = c.String("extends")
+	parser.ExtendsColumns = c.StringSlice("extends-columns")
+	parser.GormModel = c.Bool("gorm-model")
+	parser.PKFromUnique = c.Bool("pk-from-unique")
+	parser.Strict = c.Bool("strict")
+	parser.StripFPrefix = c.Bool("strip-f-prefix")
+	if prefix := c.String("strip-comment-prefix"); prefix != "" {
+		re, err := regexp.Compile("^(?:" + prefix + ")")
+		if err != nil {
+			return fmt.Errorf("解析 --strip-comment-prefix 失败: %w", err)
+		}
+		parser.StripCommentPrefix = re
+	}
+	for _, spec := range c.StringSlice("field-tag") {
+		column, tag, err := parseFieldTag(spec)
+		if err != nil {
+			return err
+		}
+		if parser.FieldTags == nil {
+			parser.FieldTags = make(map[string][]string)
+		}
+		parser.FieldTags[strings.ToLower(column)] = append(parser.FieldTags[strings.ToLower(column)], tag)
+	}
+	parser.EncryptMarkers = nil
+	for _, marker := range strings.Split(c.String("encrypt-marker"), ",") {
+		if marker = strings.TrimSpace(marker); marker != "" {
+			parser.EncryptMarkers = append(parser.EncryptMarkers, marker)
+		}
+	}
+	parser.CommentMaxWidth = c.Int("comment-max-width")
+	if parser.CommentMaxWidth < 0 {
+		return fmt.Errorf("不支持的 --comment-max-width 取值: %d", parser.CommentMaxWidth)
+	}
+	parser.WarnUnparsed = c.Bool("warn-unparsed")
+	parser.NullableStyle = c.String("nullable-style")
+	if parser.NullableStyle != "null" && parser.NullableStyle != "pointer" {
+		return fmt.Errorf("不支持的 --nullable-style 取值: %s", parser.NullableStyle)
+	}
+	parser.ArrayType = c.String("array-type")
+	if parser.ArrayType != "slice" && parser.ArrayType != "pq" {
+		return fmt.Errorf("不支持的 --array-type 取值: %s", parser.ArrayType)
+	}
+	parser.TimePrecisionComment = c.Bool("time-precision-comment")
+	parser.RequiredFrom = c.String("required-from")
+	if parser.RequiredFrom != "notnull" && parser.RequiredFrom != "nodefault" {
+		return fmt.Errorf("不支持的 --required-from 取值: %s", parser.RequiredFrom)
+	}
+	parser.ColumnTrimPrefix = c.String("column-trim-prefix")
+	parser.ColumnTrimSuffix = c.String("column-trim-suffix")
+	parser.ExcludeColumns = c.StringSlice("exclude-columns")
+	for _, spec := range c.StringSlice("entity-only-fields") {
+		field, err := parseEntityOnlyField(spec)
+		if err != nil {
+			return err
+		}
+		parser.EntityOnlyFields = append(parser.EntityOnlyFields, field)
+	}
+	results, err := parseResultSpecs(c.StringSlice("result"))
+	if err != nil {
+		return err
+	}
+	parser.Results = results
+	parser.IgnoreAsBlank = c.Bool("ignore-as-blank")
+	parser.NoGoGenerate = c.Bool("no-go-generate")
+	parser.EntityReceiver = c.String("entity-receiver")
+	if parser.EntityReceiver != "pointer" && parser.EntityReceiver != "value" {
+		return fmt.Errorf("不支持的 --entity-receiver 取值: %s", parser.EntityReceiver)
+	}
+	parser.EntityToolCmd = c.String("entitytool-cmd")
+	parser.VarcharValidate = c.String("varchar-validate")
+	if parser.VarcharValidate != "char" && parser.VarcharValidate != "byte" {
+		return fmt.Errorf("不支持的 --varchar-validate 取值: %s", parser.VarcharValidate)
+	}
+
+	sqlContent, err := readSQL(c.String("sql"))
+	if err != nil {
+		return err
+	}
+
+	// 设置输出路径
+	outputDir := c.String("output")
+	parser.OutputSuffix = c.String("output-suffix")
+	parser.OutputMode = c.String("output-mode")
+	if parser.OutputMode != "single" && parser.OutputMode != "per-table" {
+		return fmt.Errorf("不支持的 --output-mode 取值: %s", parser.OutputMode)
+	}
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("创建输出目录失败: %w", err)
+	}
 
-			fmt.Printf("成功生成文件: %s\n", parser.GetOutputPath(outputDir))
+	if err := parser.Parse(sqlContent); err != nil {
+		return err
+	}
+
+	// 增量生成时按表比较输入摘要，所有表都未变化则跳过生成，否则只重新生成有变化的表
+	var cache *generationCache
+	var hashes map[string]string
+	if c.Bool("incremental") {
+		optionsHash, err := inputHash(c)
+		if err != nil {
+			return err
+		}
+		cache = loadGenerationCache(outputDir)
+		hashes = parser.tableHashes(optionsHash)
+		parser.UnchangedTables = cache.unchangedTables(parser.SecondStructName, hashes)
+		if len(parser.UnchangedTables) == len(hashes) {
+			fmt.Printf("输入未变化，跳过生成: %s\n", parser.SecondStructName)
 			return nil
-		},
+		}
 	}
 
-	if err := app.Run(os.Args); err != nil {
-		fmt.Fprintf(os.Stderr, "错误: %v\n", err)
-		os.Exit(1)
+	// 未指定时根据 go.mod 推导输出目录的导入路径
+	parser.POImportPath = c.String("module-path")
+	if parser.POImportPath == "" {
+		parser.POImportPath = detectImportPath(outputDir)
+	}
+
+	var files []string
+	parser.NullableHelpersDir = c.String("emit-nullable-helpers-file")
+	if parser.NullableHelpersDir != "" {
+		parser.NullableHelpersImportPath = detectImportPath(parser.NullableHelpersDir)
+		helpersFile, err := parser.GenerateNullableHelpers()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("成功生成文件: %s\n", helpersFile)
+		files = append(files, helpersFile)
+	}
+
+	// 使用自定义模板时不再生成内置的结构体文件
+	var generated []string
+	if templatesDir := c.String("templates-dir"); templatesDir != "" {
+		generated, err = parser.GenerateFromTemplates(templatesDir, outputDir)
+	} else {
+		generated, err = parser.GenerateStruct(outputDir)
+	}
+	for _, warning := range parser.Warnings {
+		fmt.Fprintf(os.Stderr, "警告: %s\n", warning)
+	}
+	if err != nil {
+		return err
+	}
+	for _, file := range generated {
+		fmt.Printf("成功生成文件: %s\n", file)
+	}
+	files = append(files, generated...)
+
+	if tsOutput := c.String("ts-output"); tsOutput != "" {
+		if err := parser.GenerateTypeScript(tsOutput); err != nil {
+			return err
+		}
+		fmt.Printf("成功生成文件: %s\n", tsOutput)
+		files = append(files, tsOutput)
+	}
+
+	if cache != nil {
+		return cache.save(parser.SecondStructName, hashes, parser.TableFiles, files)
 	}
+	return nil
 }
 
 type FieldMeta struct {
-	FieldName     string
-	FieldType     string
-	Comment       string
-	Validate      string
-	OriginalField string
+	FieldName       string `json:"field_name"`
+	FieldType       string `json:"go_type"`
+	Comment         string `json:"comment"`
+	Validate        string `json:"validate,omitempty"`
+	OriginalField   string `json:"column"`
+	SQLType         string `json:"sql_type"`
+	Nullable        bool   `json:"nullable"`
+	IsPrimaryKey    bool   `json:"primary_key"`
+	IsAutoIncrement bool   `json:"auto_increment"`
+	IsArray         bool   `json:"array,omitempty"`
+	Unsigned        bool   `json:"unsigned,omitempty"`
+	Ordinal         int    `json:"ordinal"`
+	// Precision、Scale 为 DECIMAL(10,2) 等定点数的总位数和小数位
+	Precision int `json:"precision,omitempty"`
+	Scale     int `json:"scale,omitempty"`
+	// EnumValues ENUM 成员或注释中记录的取值说明
+	EnumValues []EnumValue `json:"enum_values,omitempty"`
+	Ignored    bool        `json:"ignored,omitempty"`
+	// DefaultValue 列的 DEFAULT 表达式，按SQL原样保留（字符串带引号），没有默认值或 DEFAULT NULL 时为空
+	DefaultValue string `json:"default,omitempty"`
+	// GoImport FieldType 需要导入的包，基础类型为空
+	GoImport string `json:"go_import,omitempty"`
 }
 
 type SQLParser struct {
 	TableName            string
+	TableComment         string
 	StructName           string
 	SecondStructName     string
 	Fields               []FieldMeta
 	TypeMappings         map[string]string
 	NullableTypeMappings map[string]string
+
+	// ExtendsStruct 非空时PO结构体嵌入该基础结构体，ExtendsColumns 为其拥有的列
+	ExtendsStruct  string
+	ExtendsColumns []string
+
+	// StructDocTemplate PO结构体注释的 text/template 模板，可用 .Struct、.Table、.TableComment
+	StructDocTemplate string
+
+	// JSONTags 为PO字段额外生成json标签
+	JSONTags bool
+	// BigintPKJSONString 为 BIGINT 主键的json标签追加 string 选项
+	BigintPKJSONString bool
+	// DBOmitEmpty 为 nullable 时给可空列的db标签追加 omitempty
+	DBOmitEmpty string
+	// VarcharValidate VARCHAR 的 max 校验语义：char 按字符数（默认），byte 按字符集换算后的字节数，
+	// byte 模式使用 max_bytes 规则，需要调用方通过 RegisterValidation 注册
+	VarcharValidate string
+	// ColumnTrimPrefix/ColumnTrimSuffix 生成字段名前从列名去掉的前缀/后缀
+	ColumnTrimPrefix string
+	ColumnTrimSuffix string
+	// EntityOnlyFields 只存在于entity、不对应数据库列的字段
+	EntityOnlyFields []FieldMeta
+	// ExcludeColumns 不生成字段的列，IgnoreAsBlank 时以 _ 占位保留列顺序
+	ExcludeColumns []string
+	IgnoreAsBlank  bool
+
+	// Dialect SQL方言，决定占位符风格等
+	Dialect *Dialect
+	// GenInsertParts 生成 Columns/Placeholders 用于参数化 INSERT
+	GenInsertParts bool
+	// GenFaker 生成填充随机数据的 Fake 工厂方法
+	GenFaker bool
+	// GenUpsert 生成对应方言的 upsert 语句
+	GenUpsert bool
+	// GenNamedArgs 生成返回 sql.NamedArg 的 NamedArgs 方法
+	GenNamedArgs bool
+	// GenValues 生成按列顺序返回字段值的 Values 方法
+	GenValues bool
+	// GenScanDest 生成按列顺序返回字段指针的 scanDest 方法
+	GenScanDest bool
+	// GenHashKey 生成由主键值拼接成字符串的 HashKey 方法
+	GenHashKey bool
+	// GenSchemaVar 生成记录列定义的 <结构体名>Schema 变量
+	GenSchemaVar bool
+	// GenRepoImpl 生成带 context 的仓储实现桩代码
+	GenRepoImpl bool
+	// GenFilter 生成过滤结构体及构造 WHERE 子句的方法
+	GenFilter bool
+	// GenDeepCopy 生成复制切片和指针字段的 DeepCopy 方法
+	GenDeepCopy bool
+	// GenEnumMap 为枚举列生成取值与说明的双向映射
+	GenEnumMap bool
+	// GenEnumConsts 为注释中记录取值说明的整数列生成常量
+	GenEnumConsts bool
+	// GenJSONMarshal 生成把可空字段编码为取值或 null 的 MarshalJSON
+	GenJSONMarshal bool
+	// EnumCommentRegex 提取注释中取值说明的正则，为空时使用默认规则
+	EnumCommentRegex *regexp.Regexp
+
+	// ExtraImports 类型映射文件声明的导入路径，写入PO和entity的 import
+	ExtraImports []string
+
+	// POPackage、EntityPackage 生成的PO和entity文件的包名
+	POPackage     string
+	EntityPackage string
+
+	// POImportPath PO所在包的导入路径，供转换方法引用
+	POImportPath string
+
+	// EntityReceiver entity方法的接收者风格：pointer（默认）或 value
+	EntityReceiver string
+
+	// NullableHelpersDir 非空时转换函数统一写入该目录的 nullhelpers.go，
+	// NullableHelpersImportPath 为该包的导入路径
+	NullableHelpersDir        string
+	NullableHelpersImportPath string
+
+	// NoGoGenerate 不生成 entity 的 //go:generate 指令，EntityToolCmd 为指令使用的工具名
+	NoGoGenerate  bool
+	EntityToolCmd string
+	// TimePrecisionComment 把 DATETIME(3) 等的小数秒精度写入字段注释
+	TimePrecisionComment bool
+	// RequiredFrom 生成 validate:"required" 的依据：notnull 或 nodefault
+	RequiredFrom string
+	// NullableStyle 可空列的映射方式：null（默认，sql.Null* 等包装类型）或 pointer（基础类型的指针）
+	NullableStyle string
+	// ArrayType 数组列的映射方式：slice（默认）或 pq
+	ArrayType string
+	// WarnUnparsed 对无法解析的列定义给出警告
+	WarnUnparsed bool
+	// PKFromUnique 未声明主键时使用第一个单列唯一键作为主键
+	PKFromUnique bool
+	// Concurrency 多表时并行解析和生成的协程数，不大于 1 时顺序执行
+	Concurrency int
+	// Strict 存在没有类型映射的列时返回错误
+	Strict bool
+	// TimeUTC 转换和 Values 中把时间统一转为 UTC，并在字段注释中注明
+	TimeUTC bool
+	// ORM 字段标签风格：为空时生成 db 标签，gorm 时生成 gorm 标签
+	ORM string
+	// GormModel 表的 id、created_at、updated_at、deleted_at 与 gorm.Model 兼容时嵌入 gorm.Model
+	GormModel bool
+	// ProtoTags 生成以列序号为字段编号的 protobuf 标签
+	ProtoTags bool
+	// StripFPrefix 生成字段名时去掉列名的 F 前缀，如 Fstatus -> Status
+	StripFPrefix bool
+	// StripCommentPrefix 从字段注释开头去掉的内容，如 [F001] 之类的编号
+	StripCommentPrefix *regexp.Regexp
+	// EncryptMarkers 列定义中出现任一关键字即视为加密列，非必填时生成 omitempty 校验
+	EncryptMarkers []string
+	// ColumnTypes 按列名（小写）覆盖的Go类型
+	ColumnTypes map[string]string
+	// FieldTags 按列名（小写）追加到字段标签末尾的原样标签
+	FieldTags map[string][]string
+	// CommentMaxWidth 字段行超过该宽度时注释折行写在字段上方，0 表示不限制
+	CommentMaxWidth int
+
+	// Tables SQL中包含多个 CREATE TABLE 时各表的解析结果，单表时为空
+	Tables []*SQLParser
+	// Source 解析使用的SQL，多表时各表为其建表语句及对应的 ALTER TABLE、COMMENT ON
+	Source string
+	// UnchangedTables 增量生成时输入未变化、可以跳过的表，TableFiles 为本次生成的只属于单张表的文件，均以小写表名为键
+	UnchangedTables map[string]bool
+	TableFiles      map[string][]string
+
+	// Results 需要生成的联表查询结果集，ResultStructs 为解析后的结果
+	Results       []ResultSpec
+	ResultStructs []ResultStruct
+
+	// OutputSuffix 生成文件名的后缀
+	OutputSuffix string
+	// OutputMode 生成文件的划分方式：single 所有表写入同一组文件，per-table 每张表单独一组文件
+	OutputMode string
+
+	// Warnings 解析过程中产生的警告信息
+	Warnings []string
 }
 
+// defaultStructDocTemplate 默认的PO结构体注释
+const defaultStructDocTemplate = "{{.Struct}} Po结构体"
+
+// defaultEncryptMarker 默认的加密列关键字
+const defaultEncryptMarker = "加密"
+
+// defaultOutputSuffix 默认的生成文件名后缀
+const defaultOutputSuffix = "_template"
+
 func NewSQLParser(structNames ...string) *SQLParser {
 	parser := &SQLParser{
+		Dialect:           dialects["mysql"],
+		EntityToolCmd:     "entitytool",
+		POPackage:         "po",
+		EntityPackage:     "entity",
+		OutputSuffix:      defaultOutputSuffix,
+		EncryptMarkers:    []string{defaultEncryptMarker},
+		StructDocTemplate: defaultStructDocTemplate,
+		RequiredFrom:      "notnull",
 		TypeMappings: map[string]string{
-			"INT":       "int32",
-			"SMALLINT":  "int32",
-			"TINYINT":   "int32",
-			"MEDIUMINT": "int32",
-			"BIGINT":    "int64",
-			"VARCHAR":   "string",
-			"CHAR":      "string",
-			"TEXT":      "string",
-			"JSON":      "string",
-			"DATETIME":  "datetime.DateTime",
-			"DOUBLE":    "float64",
-			"FLOAT":     "float32",
+			"INT":        "int32",
+			"SMALLINT":   "int32",
+			"TINYINT":    "int32",
+			"TINYINT(1)": "bool",
+			"BOOL":       "bool",
+			"BOOLEAN":    "bool",
+			"MEDIUMINT":  "int32",
+			"BIGINT":     "int64",
+			"VARCHAR":    "string",
+			"CHAR":       "string",
+			"TEXT":       "string",
+			"JSON":       "string",
+			"ENUM":       "string",
+			"SET":        "string",
+			"DATETIME":   "datetime.DateTime",
+			"TIMESTAMP":  "datetime.DateTime",
+			"DATE":       "time.Time",
+			"TIME":       "string",
+			"YEAR":       "int16",
+			"DOUBLE":     "float64",
+			"FLOAT":      "float32",
+			"DECIMAL":    "decimal.Decimal",
+			"NUMERIC":    "decimal.Decimal",
+
+			"BINARY":     "[]byte",
+			"VARBINARY":  "[]byte",
+			"TINYBLOB":   "[]byte",
+			"BLOB":       "[]byte",
+			"MEDIUMBLOB": "[]byte",
+			"LONGBLOB":   "[]byte",
+
+			// 无符号整数使用对应宽度的 uint 类型
+			"TINYINT UNSIGNED":   "uint8",
+			"SMALLINT UNSIGNED":  "uint16",
+			"MEDIUMINT UNSIGNED": "uint32",
+			"INT UNSIGNED":       "uint32",
+			"BIGINT UNSIGNED":    "uint64",
 		},
 		NullableTypeMappings: map[string]string{
-			"INT":       "sql.NullInt32",
-			"SMALLINT":  "sql.NullInt32",
-			"TINYINT":   "sql.NullInt32",
-			"MEDIUMINT": "sql.NullInt32",
-			"BIGINT":    "sql.NullInt64",
-			"VARCHAR":   "sql.NullString",
-			"CHAR":      "sql.NullString",
-			"TEXT":      "sql.NullString",
-			"JSON":      "sql.NullString",
-			"DATETIME":  "datetime.NullDateTime",
-			"DOUBLE":    "sql.NullFloat64",
-			"FLOAT":     "sql.NullFloat32",
+			"INT":        "sql.NullInt32",
+			"SMALLINT":   "sql.NullInt32",
+			"TINYINT":    "sql.NullInt32",
+			"TINYINT(1)": "sql.NullBool",
+			"BOOL":       "sql.NullBool",
+			"BOOLEAN":    "sql.NullBool",
+			"MEDIUMINT":  "sql.NullInt32",
+			"BIGINT":     "sql.NullInt64",
+			"VARCHAR":    "sql.NullString",
+			"CHAR":       "sql.NullString",
+			"TEXT":       "sql.NullString",
+			"JSON":       "sql.NullString",
+			"ENUM":       "sql.NullString",
+			"SET":        "sql.NullString",
+			"DATETIME":   "datetime.NullDateTime",
+			"TIMESTAMP":  "datetime.NullDateTime",
+			"DATE":       "sql.NullTime",
+			"TIME":       "sql.NullString",
+			"YEAR":       "sql.NullInt16",
+			"DOUBLE":     "sql.NullFloat64",
+			"FLOAT":      "sql.NullFloat32",
+			"DECIMAL":    "decimal.NullDecimal",
+			"NUMERIC":    "decimal.NullDecimal",
+
+			// []byte 本身可以为 nil，可空列同样使用 []byte
+			"BINARY":     "[]byte",
+			"VARBINARY":  "[]byte",
+			"TINYBLOB":   "[]byte",
+			"BLOB":       "[]byte",
+			"MEDIUMBLOB": "[]byte",
+			"LONGBLOB":   "[]byte",
+
+			// database/sql 没有无符号的可空类型：BIGINT 以下用 sql.NullInt64 足以容纳，
+			// BIGINT UNSIGNED 使用生成的 NullUint64
+			"TINYINT UNSIGNED":   "sql.NullInt64",
+			"SMALLINT UNSIGNED":  "sql.NullInt64",
+			"MEDIUMINT UNSIGNED": "sql.NullInt64",
+			"INT UNSIGNED":       "sql.NullInt64",
+			"BIGINT UNSIGNED":    nullUint64Type,
 		},
 	}
+	// 空间列默认使用 WKB 原始字节，--geo-type 可改为空间库的类型
+	for _, sqlType := range spatialTypes {
+		parser.TypeMappings[sqlType] = "[]byte"
+		parser.NullableTypeMappings[sqlType] = "[]byte"
+	}
 	if len(structNames) > 0 {
 		parser.StructName = structNames[0]
 	}
@@ -141,9 +956,93 @@ func (p *SQLParser) LoadSQLFile(filePath string) error {
 	return p.Parse(string(content))
 }
 
+// ParseReader 读取 r 中的全部SQL并解析，用于从标准输入等管道读取
+func (p *SQLParser) ParseReader(r io.Reader) error {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("读取SQL失败: %w", err)
+	}
+	return p.Parse(string(content))
+}
+
+// loadSQL 按 --sql 参数读取并解析SQL
+func loadSQL(p *SQLParser, sqlPath string) error {
+	content, err := readSQL(sqlPath)
+	if err != nil {
+		return err
+	}
+	return p.Parse(content)
+}
+
+// readSQL 读取 --sql 指定的SQL，取值为 - 时从标准输入读取
+func readSQL(sqlPath string) (string, error) {
+	var content []byte
+	var err error
+	if sqlPath == "-" {
+		content, err = io.ReadAll(os.Stdin)
+	} else {
+		content, err = os.ReadFile(sqlPath)
+	}
+	if err != nil {
+		return "", fmt.Errorf("读取SQL文件失败: %w", err)
+	}
+	return string(content), nil
+}
+
+var (
+	// 兼容 db.table、db.schema.table、`table` 以及 SHOW CREATE TABLE 输出的格式
+	tableNameRegex = regexp.MustCompile("(?i)CREATE\\s+(?:OR\\s+REPLACE\\s+)?(?:(?:GLOBAL\\s+|LOCAL\\s+)?(?:TEMPORARY|TEMP|TRANSIENT)\\s+)?" +
+		"TABLE\\s+(?:IF\\s+NOT\\s+EXISTS\\s+)?(?:[`\"]?\\w+[`\"]?\\.)*[`\"]?(\\w+?)(?:_\\{[a-zA-Z]+\\})?[`\"]?\\s*\\(")
+	// 每行一个列定义，分组依次为列名、类型、类型参数、其余约束、注释
+	fieldRegex = regexp.MustCompile(
+		"(?im)^[`\"]?(\\w+)[`\"]?[ \\t]+" +
+			"([A-Za-z][A-Za-z0-9_]*(?:[ \\t]+(?:VARYING|PRECISION|LARGE[ \\t]+OBJECT))?([ \\t]*\\([^)]*\\))?" +
+			"(?:[ \\t]+WITH(?:OUT)?[ \\t]+TIME[ \\t]+ZONE)?(?:\\[\\])?)" +
+			"(.*?)(?:[ \\t]+COMMENT[ \\t]+'(.*?)')?[ \\t]*(?:(?:FIRST|AFTER[ \\t]+\\S+)[ \\t]*)?$")
+	primaryKeyRegex     = regexp.MustCompile(`(?i)\bPRIMARY\s+KEY\b`)
+	notNullRegex        = regexp.MustCompile(`(?i)\bNOT\s+NULL\b`)
+	nullRegex           = regexp.MustCompile(`(?i)(DEFAULT\s+NULL|NULL\b)`)
+	digitsRegex         = regexp.MustCompile(`\d+`)
+	defaultRegex        = regexp.MustCompile(`(?i)\bDEFAULT\b`)
+	defaultValueRegex   = regexp.MustCompile(`(?i)\bDEFAULT\s*`)
+	defaultTokenRegex   = regexp.MustCompile(`^[^\s,()]+`)
+	autoIncrementRegex  = regexp.MustCompile(`(?i)\b(?:AUTO_?INCREMENT|IDENTITY)\b`)
+	columnCharsetRegex  = regexp.MustCompile(`(?i)\b(?:CHARACTER\s+SET|CHARSET)\s+(\w+)`)
+	tableCharsetRegex   = regexp.MustCompile(`(?i)\)\s*[^()]*\bCHARSET\s*=\s*(\w+)`)
+	directiveRegex      = regexp.MustCompile(`@(\w+):(\S+)`)
+	tableCommentRegex   = regexp.MustCompile(`(?i)\bCOMMENT\s*=\s*'([^']*)'`)
+	nestedTypeRegex     = regexp.MustCompile(`(?i)\b(?:ARRAY|STRUCT)\s*<`)
+	blankLinesRegex     = regexp.MustCompile(`\n{3,}`)
+	columnPositionRegex = regexp.MustCompile("(?i)\\b(FIRST|AFTER\\s+[`\"]?(\\w+)[`\"]?)\\s*$")
+	unsignedRegex       = regexp.MustCompile(`(?i)\bUNSIGNED\b`)
+	typeParenRegex      = regexp.MustCompile(`\s*\(\s*`)
+	typeCloseParenRegex = regexp.MustCompile(`\s*\)`)
+	primaryKeyColsRegex = regexp.MustCompile("(?i)\\bPRIMARY\\s+KEY\\s*(?:`?\\w+`?\\s*)?\\(((?:[^()]|\\(\\d+\\))*)\\)")
+	uniqueKeyRegex      = regexp.MustCompile("(?i)\\bUNIQUE\\s+(?:KEY|INDEX)?\\s*(?:`?\\w+`?\\s*)?\\(([^)]*)\\)")
+)
+
 func (p *SQLParser) Parse(sqlContent string) error {
-	tableNameRe := regexp.MustCompile(`CREATE TABLE \S+\.(\w+)(?:_\{[a-zA-Z]+\})?`)
-	tableMatch := tableNameRe.FindStringSubmatch(sqlContent)
+	sqlContent = stripPartitionClauses(stripDMLStatements(sqlContent))
+	p.Source = sqlContent
+
+	// 包含多个 CREATE TABLE 时每张表分别解析，结构体名取自表名
+	if len(splitCreateTables(sqlContent)) > 1 {
+		tables, err := p.parseTables(sqlContent)
+		if err != nil {
+			return err
+		}
+		for _, table := range tables {
+			table.SecondStructName = table.StructName
+		}
+		p.Tables = tables
+		if len(p.Results) > 0 {
+			return p.resolveResults(sqlContent)
+		}
+		return nil
+	}
+	sqlContent, commentOnTable, commentOnColumns := extractCommentOn(sqlContent)
+
+	tableMatch := tableNameRegex.FindStringSubmatch(sqlContent)
 	if len(tableMatch) > 0 {
 		p.TableName = tableMatch[1]
 		if p.StructName == "" {
@@ -151,190 +1050,1405 @@ func (p *SQLParser) Parse(sqlContent string) error {
 		}
 	}
 
-	fieldRe := regexp.MustCompile(
-		"`(\\w+)`\\s+" +
-			"([A-Za-z]+\\d*(\\(\\d+\\))?)\\s+" +
-			"(.*?)\\s+COMMENT\\s+'(.*?)'")
-	matches := fieldRe.FindAllStringSubmatch(sqlContent, -1)
+	if m := tableCommentRegex.FindStringSubmatch(sqlContent); m != nil {
+		p.TableComment = m[1]
+	} else {
+		p.TableComment = commentOnTable
+	}
+
+	// 列名可以使用反引号、双引号或不加引号，逐行匹配整理后的列定义
+	fieldContent := columnDefinitionLines(sqlContent)
+	matches := fieldRegex.FindAllStringSubmatch(fieldContent, -1)
+	var checks map[string][]checkConstraint
+	if p.Dialect.CheckValidation {
+		checks = parseCheckConstraints(fieldContent)
+	}
+
+	tableCharset := ""
+	if m := tableCharsetRegex.FindStringSubmatch(sqlContent); m != nil {
+		tableCharset = m[1]
+	}
 
+	primaryKeys := primaryKeyColumns(sqlContent)
 	for _, match := range matches {
 		if len(match) < 4 {
 			continue
 		}
 
-		sqlType := strings.ToUpper(strings.Split(match[2], "(")[0])
+		// Postgres 数组类型如 TEXT[]，按元素类型映射后包装为切片
+		// 格式化工具常输出 VARCHAR (64)，统一为 VARCHAR(64)
+		typeDef := normalizeTypeSpacing(match[2])
+		typeParams := normalizeTypeSpacing(match[3])
+		isArray := strings.HasSuffix(typeDef, "[]")
+		sqlType := sqlBaseType(strings.TrimSuffix(typeDef, "[]"))
 		otherPart := match[4]
-		comment := match[5]
+		// 不加引号时约束定义如 PRIMARY KEY (...) 也会被匹配到；ARRAY<...> 等嵌套类型暂不支持
+		if !strings.ContainsAny(match[0][:1], "`\"") && constraintKeywords[strings.ToUpper(match[1])] ||
+			nestedTypeRegex.MatchString(match[0]) {
+			continue
+		}
+		rawComment := strings.ReplaceAll(match[5], "''", "'")
+		if rawComment == "" {
+			rawComment = commentOnColumns[strings.ToLower(match[1])]
+		}
+		comment, directives := parseCommentDirectives(rawComment)
+		if p.StripCommentPrefix != nil {
+			comment = strings.TrimSpace(p.StripCommentPrefix.ReplaceAllString(comment, ""))
+		}
 
-		notNullRegex := regexp.MustCompile(`(?i)\bNOT\s+NULL\b`)
 		hasNotNull := notNullRegex.MatchString(otherPart)
 
+		// 主键列（含表级 PRIMARY KEY (...) 中的列）总是非空
 		isNullable := false
-		if !hasNotNull {
-			nullRegex := regexp.MustCompile(`(?i)(DEFAULT\s+NULL|NULL\b)`)
-			isNullable = nullRegex.MatchString(otherPart)
+		if !hasNotNull && !primaryKeyRegex.MatchString(otherPart) && !primaryKeys[strings.ToLower(match[1])] {
+			isNullable = nullRegex.MatchString(otherPart) || p.Dialect.ImplicitNullable
 		}
 
+		// UNSIGNED 整数优先使用 "INT UNSIGNED" 这类映射，方言未定义时退回有符号类型；
+		// TINYINT(1) 等映射为非整数的类型不受影响
+		mappingType := sqlType
+		if unsignedRegex.MatchString(otherPart) && isIntegerType(lookupType(p.TypeMappings, sqlType, typeParams)) &&
+			lookupType(p.TypeMappings, sqlType+" UNSIGNED", "") != "" {
+			mappingType = sqlType + " UNSIGNED"
+		}
 		var goType string
 		if isNullable {
-			goType = p.NullableTypeMappings[sqlType]
+			goType = lookupType(p.NullableTypeMappings, mappingType, typeParams)
 		} else {
-			goType = p.TypeMappings[sqlType]
+			goType = lookupType(p.TypeMappings, mappingType, typeParams)
+		}
+		if isArray {
+			goType = p.arrayType(lookupType(p.TypeMappings, sqlType, typeParams))
+		}
+		// 方言可以根据类型参数（如 NUMBER 的小数位）决定映射
+		if p.Dialect.ResolveType != nil {
+			if resolved, ok := p.Dialect.ResolveType(sqlType, typeParams, isNullable); ok {
+				goType = resolved
+			}
+		}
+		if isNullable && p.NullableStyle == "pointer" {
+			goType = pointerType(goType)
+		}
+		if override, ok := p.columnType(match[1], directives); ok {
+			goType = override
 		}
 
 		field := FieldMeta{
-			FieldName:     ToPascalCase(match[1]),
-			FieldType:     goType,
-			Comment:       comment,
-			OriginalField: match[1],
+			FieldName:       ToPascalCase(p.trimColumnName(match[1])),
+			FieldType:       goType,
+			GoImport:        p.goImport(goType),
+			Comment:         comment,
+			OriginalField:   match[1],
+			SQLType:         typeDef,
+			Nullable:        isNullable,
+			IsPrimaryKey:    primaryKeyRegex.MatchString(otherPart),
+			IsAutoIncrement: autoIncrementRegex.MatchString(otherPart) || strings.HasSuffix(sqlType, "SERIAL"),
+			IsArray:         isArray,
+			Unsigned:        unsignedRegex.MatchString(otherPart),
+			DefaultValue:    parseDefaultValue(otherPart),
 		}
 
-		if strings.HasPrefix(match[2], "VARCHAR") {
-			size := regexp.MustCompile(`\d+`).FindString(match[2])
-			field.Validate = fmt.Sprintf("validate:\"max=%s\"", size)
-		} else if strings.Contains(match[4], "加密") {
-			field.Validate = "validate:\"omitempty\""
+		var rules []string
+		// bool 的 required 会拒绝 false，布尔列不生成 required
+		required := goType != "bool" && p.isRequired(hasNotNull, field.IsAutoIncrement, otherPart, field.DefaultValue)
+		if required {
+			rules = append(rules, "required")
 		}
-
-		p.Fields = append(p.Fields, field)
-	}
-	return nil
-}
-
-func (p *SQLParser) GenerateStruct(outputDir string) (string, error) {
-	fileName := filepath.Join(outputDir, ToSnakeCase(p.SecondStructName)+"_template.go")
-
-	var builder strings.Builder
-	builder.WriteString(fmt.Sprintf("package po\n\n"))
-	builder.WriteString("import (\n\t\"git.woa.com/prd_base_pay_go/paycomm/datetime\"\n\t\"github.com/go-playground/validator/v10\"\n)\n\n")
-
-	builder.WriteString(fmt.Sprintf("// %s Po结构体\n", p.StructName))
-	builder.WriteString(fmt.Sprintf("type %s struct {\n", p.StructName))
-	for _, field := range p.Fields {
-		line := fmt.Sprintf("\t%-30s %-20s `db:\"%s\"",
-			field.FieldName, field.FieldType, field.OriginalField)
-		if field.Validate != "" {
-			line += " " + field.Validate
-		}
-		line += "`" + fmt.Sprintf(" // %s", field.Comment)
-		builder.WriteString(line + "\n")
-	}
-	builder.WriteString("}\n\n\n")
-
-	if p.SecondStructName != "" {
-		builder.WriteString(fmt.Sprintf("package entity\n\n"))
-		builder.WriteString(fmt.Sprintf("//go:generate entitytool -source=$GOFILE -entity=%s \n\n", p.SecondStructName))
-		builder.WriteString(fmt.Sprintf("// %s entity结构体\n", p.SecondStructName))
-		builder.WriteString(fmt.Sprintf("type %s struct {\n", p.SecondStructName))
-
-		for _, field := range p.Fields {
-			privateField := strings.ToLower(field.FieldName[:1]) + field.FieldName[1:]
-			fieldType := field.FieldType
-			nullableToBasic := map[string]string{
-				"sql.NullString":        "string",
-				"sql.NullInt32":         "int32",
-				"sql.NullInt64":         "int64",
-				"sql.NullFloat32":       "float32",
-				"sql.NullFloat64":       "float64",
-				"datetime.NullDateTime": "time.Time",
-			}
-			if basicType, exists := nullableToBasic[fieldType]; exists {
-				fieldType = basicType
-			} else if fieldType == "datetime.DateTime" {
-				fieldType = "time.Time"
+		if sqlType == "VARCHAR" || sqlType == "CHARACTER VARYING" || sqlType == "CHAR VARYING" {
+			size := digitsRegex.FindString(typeDef)
+			rule := fmt.Sprintf("max=%s", size)
+			if p.VarcharValidate == "byte" {
+				charset := tableCharset
+				if m := columnCharsetRegex.FindStringSubmatch(otherPart); m != nil {
+					charset = m[1]
+				}
+				rule = fmt.Sprintf("max_bytes=%d", varcharMaxBytes(size, charset))
 			}
-			line := fmt.Sprintf("\t%-30s %-20s // %s",
-				privateField,
-				fieldType,
-				field.Comment)
-			builder.WriteString(line + "\n")
-		}
-		builder.WriteString("}\n\n")
-
-		builder.WriteString(fmt.Sprintf("func (e *%s) Validate() error {\n", p.SecondStructName))
-		builder.WriteString("\treturn nil\n}\n\n")
-
-		// 生成PO到Entity的转换方法
-		builder.WriteString(fmt.Sprintf("// To%sEntity po to entity\n", p.SecondStructName))
-		builder.WriteString(fmt.Sprintf("func To%sEntity(p *po.%s) (*entity.%s, error) {\n", p.SecondStructName, p.StructName, p.SecondStructName))
-		builder.WriteString(fmt.Sprintf("\treturn entity.New%sBuilder().\n", p.SecondStructName))
-		for _, field := range p.Fields {
-			privateField := strings.ToLower(field.FieldName[:1]) + field.FieldName[1:]
-			fieldAccess := field.FieldName
-			switch field.FieldType {
-			case "sql.NullString":
-				fieldAccess += ".String"
-			case "datetime.NullDateTime":
-				fieldAccess += ".Time.Time()"
-			case "datetime.DateTime":
-				fieldAccess += ".Time()"
-			case "sql.NullInt32":
-				fieldAccess += ".Int32"
-			case "sql.NullInt64":
-				fieldAccess += ".Int64"
-			case "sql.NullFloat64":
-				fieldAccess += ".Float64"
-			}
-			builder.WriteString(fmt.Sprintf("\t\tWith%s(p.%s).\n",
-				strings.Title(privateField),
-				fieldAccess))
-		}
-		builder.WriteString("\t\tBuild()\n}\n\n")
-
-		// 生成Entity到PO的转换方法
-		builder.WriteString(fmt.Sprintf("// To%s entity to po\n", p.StructName))
-		builder.WriteString(fmt.Sprintf("func To%s(e *entity.%s) (*po.%s, error) {\n",
-			p.StructName, p.SecondStructName, p.StructName))
-		builder.WriteString(fmt.Sprintf("\treturn &po.%s{\n", p.StructName))
-		for _, field := range p.Fields {
-			privateField := strings.ToLower(field.FieldName[:1]) + field.FieldName[1:]
-			fieldAccess := fmt.Sprintf("e.%s()", strings.Title(privateField))
-			switch field.FieldType {
-			case "sql.NullString":
-				fieldAccess = fmt.Sprintf("sql.NullString{String: %s, Valid: true}", fieldAccess)
-			case "datetime.NullDateTime":
-				fieldAccess = fmt.Sprintf("TimeToNullDateTime(%s)", fieldAccess)
-			case "datetime.DateTime":
-				fieldAccess = fmt.Sprintf("datetime.NewDateTime(%s)", fieldAccess)
-			case "sql.NullInt32", "sql.NullInt64", "sql.NullFloat64":
-				baseType := strings.TrimPrefix(field.FieldType, "sql.Null")
-				fieldAccess = fmt.Sprintf("%s{%s: %s, Valid: true}",
-					field.FieldType,
-					strings.Title(baseType),
-					fieldAccess)
-			}
-			builder.WriteString(fmt.Sprintf("\t\t%-15s: %s,\n",
-				field.FieldName,
-				fieldAccess))
+			rules = append(rules, rule)
+		} else if p.isEncrypted(otherPart+" "+rawComment) && !required {
+			// required 与 omitempty 互相矛盾，必填的加密列只保留 required
+			rules = append(rules, "omitempty")
 		}
-		builder.WriteString("\t}, nil\n}\n\n")
-
-		needTimeFunc := false
-		for _, field := range p.Fields {
-			if field.FieldType == "datetime.NullDateTime" {
-				needTimeFunc = true
-				break
+		if sqlType == "ENUM" {
+			if members := parseEnumMembers(typeParams); len(members) > 0 {
+				rules = append(rules, oneOfRule(members))
 			}
 		}
-		if needTimeFunc {
-			builder.WriteString(`// TimeToNullDateTime Time 转成 datetime.NullDateTime
-func TimeToNullDateTime(t time.Time) datetime.NullDateTime {
-	if !t.IsZero() {
-		return datetime.NullDateTime{Time: datetime.NewDateTime(t), Valid: true}
-	}
-	return datetime.NullDateTime{Valid: false}
-}`)
+		rules = append(rules, checkRules(checks[strings.ToLower(match[1])], goType)...)
+		if custom, ok := directives["validate"]; ok {
+			rules = mergeValidateRules(rules, strings.Split(custom, ","))
+		}
+		if len(rules) > 0 {
+			field.Validate = fmt.Sprintf("validate:\"%s\"", strings.Join(rules, ","))
 		}
-	}
 
-	if err := os.WriteFile(fileName, []byte(builder.String()), 0644); err != nil {
-		return "", fmt.Errorf("写入文件失败: %w", err)
-	}
-	return fileName, nil
+		if p.TimeUTC && basicType(field.FieldType) == "time.Time" {
+			field.Comment = strings.TrimSpace(field.Comment + " (UTC)")
+		}
+
+		// 可选地把时间类型的小数秒精度记录到注释中
+		if p.TimePrecisionComment && (sqlType == "DATETIME" || sqlType == "TIMESTAMP" || sqlType == "TIME") {
+			if m := sqlTypeSizeRegex.FindStringSubmatch(typeDef); m != nil {
+				field.Comment = strings.TrimSpace(fmt.Sprintf("%s（小数秒精度: %s）", field.Comment, m[1]))
+			}
+		}
+
+		if sqlType == "DECIMAL" || sqlType == "NUMERIC" {
+			field.Precision, field.Scale = decimalPrecisionScale(typeParams)
+		}
+
+		if sqlType == "ENUM" {
+			field.EnumValues = parseEnumMembers(typeParams)
+		} else if isIntegerType(basicType(goType)) {
+			field.EnumValues = p.parseEnumComment(field.Comment)
+		}
+
+		if name, ok := directives["field"]; ok {
+			field.FieldName = name
+		}
+
+		// 排除的列在编号后才移除，不改变其余列的序号
+		if p.isExcludedColumn(field.OriginalField) && p.IgnoreAsBlank {
+			field.Ignored = true
+		}
+
+		// ALTER TABLE ... ADD COLUMN 可能带有 FIRST / AFTER col 指定位置
+		if m := columnPositionRegex.FindStringSubmatch(match[0]); m != nil {
+			p.insertField(field, m[2])
+			continue
+		}
+		p.Fields = append(p.Fields, field)
+	}
+
+	// 序号按最终列顺序编号（含 FIRST/AFTER 调整），从1开始，--exclude-columns 排除的列同样占用序号，
+	// 排除列后 protobuf 字段编号等保持不变
+	fields := p.Fields[:0]
+	for i, field := range p.Fields {
+		field.Ordinal = i + 1
+		if p.isExcludedColumn(field.OriginalField) && !p.IgnoreAsBlank {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	p.Fields = fields
+
+	p.applyPrimaryKeyClauses(sqlContent)
+	if p.PKFromUnique {
+		p.applyUniqueAsPrimaryKey(sqlContent)
+	}
+	if p.GormModel {
+		p.applyGormModel()
+	}
+	if p.WarnUnparsed {
+		p.warnUnparsedColumns(sqlContent)
+	}
+	if p.Strict {
+		if err := p.checkUnmappedTypes(); err != nil {
+			return err
+		}
+	}
+	if err := p.checkNameCollisions(); err != nil {
+		return err
+	}
+	if len(p.Results) > 0 {
+		return p.resolveResults(sqlContent)
+	}
+	return nil
+}
+
+// warnUnparsedColumns 对无法解析或没有类型映射的列给出警告，避免列被静默丢弃
+func (p *SQLParser) warnUnparsedColumns(sqlContent string) {
+	parsed := make(map[string]bool, len(p.Fields))
+	for _, field := range p.Fields {
+		parsed[strings.ToLower(field.OriginalField)] = true
+		if field.FieldType == "" {
+			p.Warnings = append(p.Warnings,
+				fmt.Sprintf("列 %s 的类型 %s 没有对应的Go类型", field.OriginalField, field.SQLType))
+		}
+	}
+	for _, definition := range splitDefinitions(createTableBody(stripSQLComments(sqlContent))) {
+		column := columnDefinitionName(definition)
+		if column == "" || parsed[strings.ToLower(column)] || p.isExcludedColumn(column) {
+			continue
+		}
+		if nestedTypeRegex.MatchString(definition) {
+			p.Warnings = append(p.Warnings, fmt.Sprintf("列 %s 使用了暂不支持的 ARRAY/STRUCT 类型，已跳过", column))
+			continue
+		}
+		p.Warnings = append(p.Warnings, fmt.Sprintf("列 %s 无法解析，已跳过: %s", column, definition))
+	}
+}
+
+// mergeValidateRules 合并自动推导的规则和 @validate: 指令，同名规则以指令为准
+func mergeValidateRules(rules, custom []string) []string {
+	ruleName := func(rule string) string {
+		return strings.SplitN(rule, "=", 2)[0]
+	}
+	for _, rule := range custom {
+		if rule == "" {
+			continue
+		}
+		replaced := false
+		for i := range rules {
+			if ruleName(rules[i]) == ruleName(rule) {
+				rules[i] = rule
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// parseCommentDirectives 提取注释中形如 @name:value 的指令，返回去掉指令后的注释
+func parseCommentDirectives(comment string) (string, map[string]string) {
+	directives := make(map[string]string)
+	for _, match := range directiveRegex.FindAllStringSubmatch(comment, -1) {
+		directives[match[1]] = match[2]
+	}
+	if len(directives) == 0 {
+		return comment, directives
+	}
+	return strings.Join(strings.Fields(directiveRegex.ReplaceAllString(comment, "")), " "), directives
+}
+
+// charsetMaxBytes 各字符集单个字符占用的最大字节数
+var charsetMaxBytes = map[string]int{
+	"utf8mb4": 4,
+	"utf8mb3": 3,
+	"utf8":    3,
+	"gb18030": 4,
+	"gbk":     2,
+	"gb2312":  2,
+	"big5":    2,
+	"ucs2":    2,
+	"utf16":   4,
+	"utf32":   4,
+	"latin1":  1,
+	"ascii":   1,
+	"binary":  1,
+}
+
+// varcharMaxBytes 根据字符集把 VARCHAR 的字符长度换算为字节长度，未知字符集按 utf8mb4 处理
+func varcharMaxBytes(size string, charset string) int {
+	chars, _ := strconv.Atoi(size)
+	bytesPerChar, ok := charsetMaxBytes[strings.ToLower(charset)]
+	if !ok {
+		bytesPerChar = charsetMaxBytes["utf8mb4"]
+	}
+	return chars * bytesPerChar
+}
+
+// isRequired 按 RequiredFrom 判断是否生成 required 校验：notnull 为 NOT NULL 列，但默认值为零值或由数据库计算的列除外，
+// 因为 required 会拒绝零值，而这些列不赋值时正是依赖默认值；nodefault 仅为没有默认值的 NOT NULL 列；
+// 自增列（含 SERIAL）由数据库生成，始终不要求
+func (p *SQLParser) isRequired(hasNotNull, autoIncrement bool, otherPart, defaultValue string) bool {
+	if !hasNotNull || autoIncrement {
+		return false
+	}
+	if p.RequiredFrom == "nodefault" {
+		return !defaultRegex.MatchString(otherPart)
+	}
+	return defaultValue == "" || !isZeroOrComputedDefault(defaultValue)
+}
+
+// isZeroOrComputedDefault 判断默认值是否为 0、空串、false 等零值，或 CURRENT_TIMESTAMP、(expr) 等由数据库计算的表达式
+func isZeroOrComputedDefault(value string) bool {
+	literal := value
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		literal = value[1 : len(value)-1]
+	} else if _, err := strconv.ParseFloat(value, 64); err != nil && !strings.EqualFold(value, "TRUE") && !strings.EqualFold(value, "FALSE") {
+		return true
+	}
+	if number, err := strconv.ParseFloat(literal, 64); err == nil {
+		return number == 0
+	}
+	return literal == "" || strings.EqualFold(literal, "FALSE")
+}
+
+// isEncrypted 判断列的约束或注释中是否包含加密关键字
+func (p *SQLParser) isEncrypted(definition string) bool {
+	for _, marker := range p.EncryptMarkers {
+		if strings.Contains(definition, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseDefaultValue 提取列定义中 DEFAULT 之后的表达式，支持数字、字符串、
+// CURRENT_TIMESTAMP(3) 这类函数调用和 MySQL 8 的 (expr)；DEFAULT NULL 不算默认值
+func parseDefaultValue(otherPart string) string {
+	loc := defaultValueRegex.FindStringIndex(otherPart)
+	if loc == nil {
+		return ""
+	}
+	rest := otherPart[loc[1]:]
+	var value string
+	switch {
+	case rest == "":
+		return ""
+	case rest[0] == '\'':
+		end := 1
+		for end < len(rest) {
+			if rest[end] == '\'' {
+				if end+1 < len(rest) && rest[end+1] == '\'' {
+					end += 2
+					continue
+				}
+				break
+			}
+			end++
+		}
+		value = rest[:minInt(end+1, len(rest))]
+	case rest[0] == '(':
+		inner, ok := parenContent(rest)
+		if !ok {
+			return ""
+		}
+		value = "(" + inner + ")"
+	default:
+		value = defaultTokenRegex.FindString(rest)
+		if strings.EqualFold(value, "NULL") {
+			return ""
+		}
+		if strings.HasPrefix(rest[len(value):], "(") {
+			if args, ok := parenContent(rest[len(value):]); ok {
+				value += "(" + args + ")"
+			}
+		}
+	}
+	return value
+}
+
+// pqArrayTypes 元素类型对应的 github.com/lib/pq 数组类型
+var pqArrayTypes = map[string]string{
+	"string":  "pq.StringArray",
+	"int64":   "pq.Int64Array",
+	"int32":   "pq.Int32Array",
+	"float64": "pq.Float64Array",
+	"float32": "pq.Float32Array",
+	"bool":    "pq.BoolArray",
+	"[]byte":  "pq.ByteaArray",
+}
+
+// arrayType 返回数组列的Go类型，ArrayType 为 pq 时使用 lib/pq 的数组类型；
+// 切片本身可以为 nil，因此可空数组列使用相同类型
+func (p *SQLParser) arrayType(elemType string) string {
+	if elemType == "" {
+		return ""
+	}
+	if p.ArrayType == "pq" {
+		if pqType, ok := pqArrayTypes[elemType]; ok {
+			return pqType
+		}
+	}
+	return "[]" + elemType
+}
+
+// insertField 把字段插入到 after 列之后，after 为空表示 FIRST；找不到 after 列时追加到末尾
+func (p *SQLParser) insertField(field FieldMeta, after string) {
+	index := 0
+	if after != "" {
+		index = -1
+		for i := range p.Fields {
+			if strings.EqualFold(p.Fields[i].OriginalField, after) {
+				index = i + 1
+				break
+			}
+		}
+		if index < 0 {
+			p.Warnings = append(p.Warnings, fmt.Sprintf("列 %s 的 AFTER 目标 %s 不存在，追加到末尾", field.OriginalField, after))
+			p.Fields = append(p.Fields, field)
+			return
+		}
+	}
+	p.Fields = append(p.Fields, FieldMeta{})
+	copy(p.Fields[index+1:], p.Fields[index:])
+	p.Fields[index] = field
+}
+
+// applyPrimaryKeyClauses 按表级约束和 ALTER TABLE ADD PRIMARY KEY 中的 PRIMARY KEY [name] (col1, col2) 标记主键列，
+// 联合主键的每一列都标记为主键
+func (p *SQLParser) applyPrimaryKeyClauses(sqlContent string) {
+	columns := primaryKeyColumns(sqlContent)
+	for i := range p.Fields {
+		if columns[strings.ToLower(p.Fields[i].OriginalField)] {
+			p.Fields[i].IsPrimaryKey = true
+		}
+	}
+}
+
+// primaryKeyColumns 返回 PRIMARY KEY (col1, col2) 子句中的列名（小写），列名后的前缀索引长度会被忽略
+func primaryKeyColumns(sqlContent string) map[string]bool {
+	columns := make(map[string]bool)
+	for _, match := range primaryKeyColsRegex.FindAllStringSubmatch(sqlContent, -1) {
+		for _, column := range strings.Split(match[1], ",") {
+			// 去掉前缀索引的长度，如 `name`(10)
+			column, _, _ = strings.Cut(column, "(")
+			columns[strings.ToLower(strings.Trim(strings.TrimSpace(column), "`\""))] = true
+		}
+	}
+	return columns
+}
+
+// applyUniqueAsPrimaryKey 未声明主键时，将第一个单列唯一键视为主键
+func (p *SQLParser) applyUniqueAsPrimaryKey(sqlContent string) {
+	if primaryKeyRegex.MatchString(sqlContent) {
+		return
+	}
+	for _, match := range uniqueKeyRegex.FindAllStringSubmatch(sqlContent, -1) {
+		columns := strings.Split(match[1], ",")
+		if len(columns) != 1 {
+			continue
+		}
+		column := strings.Trim(strings.TrimSpace(columns[0]), "`\"")
+		for i := range p.Fields {
+			if strings.EqualFold(p.Fields[i].OriginalField, column) {
+				p.Fields[i].IsPrimaryKey = true
+				p.Warnings = append(p.Warnings,
+					fmt.Sprintf("表 %s 未声明主键，使用唯一键列 %s 作为主键", p.TableName, column))
+				return
+			}
+		}
+	}
+}
+
+// tableSchema WriteJSON 输出的单表结构
+type tableSchema struct {
+	Table        string      `json:"table"`
+	TableComment string      `json:"table_comment,omitempty"`
+	Struct       string      `json:"struct"`
+	Columns      []FieldMeta `json:"columns"`
+}
+
+func (p *SQLParser) schema() tableSchema {
+	schema := tableSchema{
+		Table:        p.TableName,
+		TableComment: p.TableComment,
+		Struct:       p.StructName,
+		Columns:      p.Fields,
+	}
+	if schema.Columns == nil {
+		schema.Columns = []FieldMeta{}
+	}
+	return schema
+}
+
+// WriteJSON 以JSON格式输出解析结果，供其他工具消费；多表时输出数组
+func (p *SQLParser) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	if len(p.Tables) > 0 {
+		schemas := make([]tableSchema, 0, len(p.Tables))
+		for _, table := range p.Tables {
+			schemas = append(schemas, table.schema())
+		}
+		return encoder.Encode(schemas)
+	}
+	return encoder.Encode(p.schema())
+}
+
+// WriteSummary 以表格形式输出解析结果，多表时依次输出每张表
+func (p *SQLParser) WriteSummary(w io.Writer) error {
+	if len(p.Tables) > 0 {
+		for i, table := range p.Tables {
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			if err := table.WriteSummary(w); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "table: %s\n", p.TableName)
+	fmt.Fprintln(tw, "COLUMN\tSQL TYPE\tGO TYPE\tNULL\tPK\tCOMMENT")
+	for _, field := range p.Fields {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%t\t%t\t%s\n",
+			field.OriginalField, field.SQLType, field.FieldType,
+			field.Nullable, field.IsPrimaryKey, field.Comment)
+	}
+	return tw.Flush()
+}
+
+// GenerateStruct 把PO和entity分别写入各自的文件，返回生成的文件路径；
+// OutputMode 为 per-table 时每张表单独写入以表名命名的文件
+func (p *SQLParser) GenerateStruct(outputDir string) ([]string, error) {
+	// 单表时 Tables 为空，使用解析器自身
+	tables := p.Tables
+	if len(tables) == 0 {
+		tables = []*SQLParser{p}
+	}
+	if p.OutputMode != "per-table" {
+		poFile, entityFile := p.GetOutputPaths(outputDir)
+		return p.writeFiles(poFile, entityFile, tables, true)
+	}
+
+	var files []string
+	for i, table := range tables {
+		// 共享的类型和转换函数只写入第一张表的文件，避免同一个包中重复定义；
+		// 它们依赖所有表，因此第一张表不因增量生成跳过
+		key := strings.ToLower(table.TableName)
+		if i > 0 && p.UnchangedTables[key] {
+			continue
+		}
+		poFile, entityFile := p.outputPaths(outputDir, ToSnakeCase(table.TableName))
+		written, err := p.writeFiles(poFile, entityFile, tables[i:i+1], i == 0)
+		if err != nil {
+			return nil, err
+		}
+		p.recordTableFiles(key, written)
+		files = append(files, written...)
+	}
+	return files, nil
+}
+
+// writeFiles 把 tables 的PO和entity写入 poFile、entityFile，shared 为 true 时同时写入
+// 所有表共用的类型和转换函数；每张表的代码生成后即格式化写入文件，不在内存中拼接整个文件
+func (p *SQLParser) writeFiles(poFile, entityFile string, tables []*SQLParser, shared bool) ([]string, error) {
+	withEntity := p.SecondStructName != ""
+
+	// import 按代码中实际引用的包生成
+	imports := p.availableImports()
+	po, err := newSourceWriter(poFile, p.POPackage, imports)
+	if err != nil {
+		return nil, err
+	}
+	defer po.Close()
+	files := []string{poFile}
+
+	var entity *sourceWriter
+	if withEntity {
+		entityImports := p.availableImports()
+		if p.POImportPath != "" {
+			entityImports[p.POPackage] = p.POImportPath
+		}
+		if p.NullableHelpersImportPath != "" {
+			entityImports[filepath.Base(p.NullableHelpersDir)] = p.NullableHelpersImportPath
+		}
+		if entity, err = newSourceWriter(entityFile, p.EntityPackage, entityImports); err != nil {
+			return nil, err
+		}
+		defer entity.Close()
+		files = append(files, entityFile)
+	}
+
+	// 每批最多 Concurrency 张表并行生成和格式化，再按表的顺序写入，输出与顺序生成一致
+	type chunk struct {
+		code string
+		err  error
+	}
+	batchSize := maxInt(p.Concurrency, 1)
+	poChunks, entityChunks := make([]chunk, batchSize), make([]chunk, batchSize)
+	for start := 0; start < len(tables); start += batchSize {
+		batch := tables[start:minInt(start+batchSize, len(tables))]
+		err := forEachParallel(p.Concurrency, len(batch), func(i int) error {
+			var builder strings.Builder
+			if err := batch[i].writePO(&builder); err != nil {
+				return err
+			}
+			poChunks[i].code, poChunks[i].err = formatSource(builder.String())
+			if withEntity {
+				builder.Reset()
+				batch[i].writeEntity(&builder)
+				entityChunks[i].code, entityChunks[i].err = formatSource(builder.String())
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		for i := range batch {
+			po.WriteFormatted(poChunks[i].code, poChunks[i].err)
+			if withEntity {
+				entity.WriteFormatted(entityChunks[i].code, entityChunks[i].err)
+			}
+		}
+	}
+	for _, table := range tables {
+		if table != p {
+			p.Warnings = append(p.Warnings, table.Warnings...)
+		}
+	}
+	if shared {
+		p.writeShared(po, entity)
+	}
+
+	// 生成的代码有语法错误时不写出文件，返回格式化错误
+	closeErr := po.Close()
+	if withEntity {
+		if err := entity.Close(); closeErr == nil {
+			closeErr = err
+		}
+	}
+	if closeErr != nil {
+		return nil, closeErr
+	}
+	return files, nil
+}
+
+// writeShared 按所有表的字段类型写入需要的共用类型和转换函数，entity 为 nil 时只写PO部分
+func (p *SQLParser) writeShared(po, entity *sourceWriter) {
+	tables := p.Tables
+	if len(tables) == 0 {
+		tables = []*SQLParser{p}
+	}
+	needNullUint64, needGeometry, needTimeFunc, needPointerFuncs := false, false, false, false
+	for _, table := range tables {
+		for _, field := range table.Fields {
+			needNullUint64 = needNullUint64 || (field.FieldType == nullUint64Type && !field.Ignored)
+			needGeometry = needGeometry || (field.FieldType == geometryType && !field.Ignored)
+			needTimeFunc = needTimeFunc || field.FieldType == "datetime.NullDateTime"
+			needPointerFuncs = needPointerFuncs || (isBasicPointer(field.FieldType) && !field.Ignored)
+		}
+	}
+	// 结果集由顶层解析器在所有表中解析，各表的副本中为空
+	if len(p.ResultStructs) > 0 {
+		var builder strings.Builder
+		p.writeResults(&builder)
+		po.WriteChunk(builder.String())
+	}
+	if needNullUint64 {
+		po.WriteChunk(nullUint64Def)
+	}
+	if needGeometry {
+		po.WriteChunk(geometryDef)
+	}
+	if p.GenSchemaVar {
+		po.WriteChunk(columnDefDef)
+	}
+	// 使用共享的转换函数文件时不再在每个文件中重复生成
+	if entity != nil && needTimeFunc && p.NullableHelpersDir == "" {
+		entity.WriteChunk(timeToNullDateTimeFunc)
+	}
+	if entity != nil && needPointerFuncs && p.NullableHelpersDir == "" {
+		entity.WriteChunk(pointerFuncs)
+	}
+}
+
+// writePO 写入一张表的PO结构体及按参数生成的辅助代码，不含 package 和 import
+func (p *SQLParser) writePO(builder *strings.Builder) error {
+	doc, err := p.structDoc()
+	if err != nil {
+		return err
+	}
+	builder.WriteString(doc)
+	builder.WriteString(fmt.Sprintf("type %s struct {\n", p.StructName))
+	if p.ExtendsStruct != "" {
+		builder.WriteString(fmt.Sprintf("\t%s\n", p.ExtendsStruct))
+	}
+	// 各列按最长的一项补齐，json 等额外标签或较长的类型加宽行时注释仍保持对齐
+	nameWidth, typeWidth, tagWidth := 30, 20, 0
+	for _, field := range p.Fields {
+		if p.isBaseColumn(field) {
+			continue
+		}
+		nameWidth = maxInt(nameWidth, len(field.FieldName))
+		typeWidth = maxInt(typeWidth, len(field.FieldType))
+		if !field.Ignored {
+			tagWidth = maxInt(tagWidth, len(p.fieldTag(field))+2)
+		}
+	}
+	for _, field := range p.Fields {
+		if p.isBaseColumn(field) {
+			continue
+		}
+		// 被排除的列以空白标识符占位，保持按位置扫描时的列顺序
+		if field.Ignored {
+			p.writeFieldLine(builder, fmt.Sprintf("\t%-*s %-*s %-*s",
+				nameWidth, "_", typeWidth, field.FieldType, tagWidth, ""), field.Comment)
+			continue
+		}
+		line := fmt.Sprintf("\t%-*s %-*s %-*s",
+			nameWidth, field.FieldName, typeWidth, field.FieldType, tagWidth, "`"+p.fieldTag(field)+"`")
+		p.writeFieldLine(builder, line, field.Comment)
+	}
+	builder.WriteString("}\n\n")
+
+	if p.GenInsertParts {
+		p.writeInsertParts(builder)
+	}
+	if p.GenFaker {
+		p.writeFaker(builder)
+	}
+	if p.GenUpsert {
+		p.writeUpsert(builder)
+	}
+	if p.GenNamedArgs {
+		p.writeNamedArgs(builder)
+	}
+	if p.GenValues {
+		p.writeValues(builder)
+	}
+	if p.GenScanDest {
+		p.writeScanDest(builder)
+	}
+	if p.GenHashKey {
+		p.writeHashKey(builder)
+	}
+	if p.GenSchemaVar {
+		p.writeSchemaVar(builder)
+	}
+	if p.GenRepoImpl {
+		p.writeRepoImpl(builder)
+	}
+	if p.GenFilter {
+		p.writeFilter(builder)
+	}
+	if p.GenDeepCopy {
+		p.writeDeepCopy(builder)
+	}
+	if p.GenEnumMap {
+		p.writeEnumMaps(builder)
+	}
+	if p.GenEnumConsts {
+		p.writeEnumConsts(builder)
+	}
+	if p.GenJSONMarshal {
+		p.writeJSONMarshal(builder)
+	}
+	return nil
 }
 
-func (p *SQLParser) GetOutputPath(outputDir string) string {
-	return filepath.Join(outputDir, ToSnakeCase(p.SecondStructName)+"_template.go")
+// writeEntity 写入一张表的entity结构体、构造器和PO与entity的转换函数，不含 package 和 import
+func (p *SQLParser) writeEntity(builder *strings.Builder) {
+	if !p.NoGoGenerate {
+		builder.WriteString(fmt.Sprintf("//go:generate %s -source=$GOFILE -entity=%s\n\n", p.EntityToolCmd, p.SecondStructName))
+	}
+	builder.WriteString(fmt.Sprintf("// %s entity结构体\n", p.SecondStructName))
+	builder.WriteString(fmt.Sprintf("type %s struct {\n", p.SecondStructName))
+
+	for _, field := range p.Fields {
+		if field.Ignored {
+			continue
+		}
+		privateField := privateName(field.FieldName)
+		fieldType := basicType(field.FieldType)
+		line := fmt.Sprintf("\t%-30s %-20s",
+			privateField,
+			fieldType)
+		p.writeFieldLine(builder, line, field.Comment)
+	}
+	for _, field := range p.EntityOnlyFields {
+		builder.WriteString(fmt.Sprintf("\t%-30s %-20s // entity独有字段，不对应数据库列\n",
+			privateName(field.FieldName),
+			field.FieldType))
+	}
+	builder.WriteString("}\n\n")
+
+	builder.WriteString(fmt.Sprintf("func (e %s%s) Validate() error {\n", p.entityReceiverPrefix(), p.SecondStructName))
+	builder.WriteString("\treturn nil\n}\n\n")
+
+	// 生成PO到Entity的转换方法
+	builder.WriteString(fmt.Sprintf("// To%sEntity po to entity\n", p.SecondStructName))
+	builder.WriteString(fmt.Sprintf("func To%sEntity(p *%s%s) (*%s, error) {\n",
+		p.SecondStructName, p.poQualifier(), p.StructName, p.SecondStructName))
+	builder.WriteString(fmt.Sprintf("\treturn New%sBuilder().\n", p.SecondStructName))
+	for _, field := range p.Fields {
+		if field.Ignored {
+			continue
+		}
+		privateField := privateName(field.FieldName)
+		fieldAccess := "p." + field.FieldName + valueAccessor(field.FieldType) + p.utcSuffix(field)
+		// 指针字段为 nil 时取零值
+		if isBasicPointer(field.FieldType) {
+			fieldAccess = fmt.Sprintf("%sDerefOrZero(p.%s)%s", p.helpersQualifier(), field.FieldName, p.utcSuffix(field))
+		}
+		builder.WriteString(fmt.Sprintf("\t\tWith%s(%s).\n",
+			strings.Title(privateField),
+			fieldAccess))
+	}
+	// entity独有字段没有对应的PO字段，需要由调用方计算后设置
+	for _, field := range p.EntityOnlyFields {
+		builder.WriteString(fmt.Sprintf("\t\t// TODO: 计算entity独有字段 %s 后调用 With%s\n",
+			field.FieldName, strings.Title(privateName(field.FieldName))))
+	}
+	builder.WriteString("\t\tBuild()\n}\n\n")
+
+	// 生成Entity到PO的转换方法
+	builder.WriteString(fmt.Sprintf("// To%s entity to po\n", p.StructName))
+	builder.WriteString(fmt.Sprintf("func To%s(e %s%s) (*%s%s, error) {\n",
+		p.StructName, p.entityReceiverPrefix(), p.SecondStructName, p.poQualifier(), p.StructName))
+	builder.WriteString(fmt.Sprintf("\treturn &%s%s{\n", p.poQualifier(), p.StructName))
+	var baseLines []string
+	for _, field := range p.Fields {
+		if field.Ignored {
+			continue
+		}
+		privateField := privateName(field.FieldName)
+		fieldAccess := fmt.Sprintf("e.%s()%s", strings.Title(privateField), p.utcSuffix(field))
+		switch field.FieldType {
+		case "sql.NullString":
+			fieldAccess = fmt.Sprintf("sql.NullString{String: %s, Valid: true}", fieldAccess)
+		case "datetime.NullDateTime":
+			fieldAccess = fmt.Sprintf("%sTimeToNullDateTime(%s)", p.helpersQualifier(), fieldAccess)
+		case "datetime.DateTime":
+			fieldAccess = fmt.Sprintf("datetime.NewDateTime(%s)", fieldAccess)
+		case "decimal.NullDecimal":
+			fieldAccess = fmt.Sprintf("decimal.NullDecimal{Decimal: %s, Valid: true}", fieldAccess)
+		case nullUint64Type:
+			fieldAccess = fmt.Sprintf("%s%s{Uint64: %s, Valid: true}", p.poQualifier(), nullUint64Type, fieldAccess)
+		case geometryType:
+			fieldAccess = fmt.Sprintf("%s%s{T: %s}", p.poQualifier(), geometryType, fieldAccess)
+		case "sql.NullBool", "sql.NullInt16", "sql.NullInt32", "sql.NullInt64", "sql.NullFloat64", "sql.NullTime":
+			baseType := strings.TrimPrefix(field.FieldType, "sql.Null")
+			fieldAccess = fmt.Sprintf("%s{%s: %s, Valid: true}",
+				field.FieldType,
+				strings.Title(baseType),
+				fieldAccess)
+		case "pgtype.Text", "pgtype.Int2", "pgtype.Int4", "pgtype.Int8", "pgtype.Float4", "pgtype.Float8", "pgtype.Bool",
+			"pgtype.Date", "pgtype.Timestamp", "pgtype.Timestamptz":
+			fieldAccess = fmt.Sprintf("%s{%s: %s, Valid: true}",
+				field.FieldType,
+				strings.TrimPrefix(valueAccessor(field.FieldType), "."),
+				fieldAccess)
+		default:
+			if isBasicPointer(field.FieldType) {
+				fieldAccess = fmt.Sprintf("%sPtrOf(%s)", p.helpersQualifier(), fieldAccess)
+			}
+		}
+		if p.isBaseColumn(field) {
+			baseLines = append(baseLines, fmt.Sprintf("\t\t\t%-15s: %s,\n", field.FieldName, fieldAccess))
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("\t\t%-15s: %s,\n",
+			field.FieldName,
+			fieldAccess))
+	}
+	// 基础表的字段通过嵌入结构体赋值
+	if p.ExtendsStruct != "" {
+		// 其他包的结构体（如 gorm.Model）嵌入后字段名为类型名，不加PO包前缀
+		key, structType := p.ExtendsStruct, p.poQualifier()+p.ExtendsStruct
+		if i := strings.LastIndex(p.ExtendsStruct, "."); i >= 0 {
+			key, structType = p.ExtendsStruct[i+1:], p.ExtendsStruct
+		}
+		builder.WriteString(fmt.Sprintf("\t\t%s: %s{\n", key, structType))
+		builder.WriteString(strings.Join(baseLines, ""))
+		builder.WriteString("\t\t},\n")
+	}
+	builder.WriteString("\t}, nil\n}\n\n")
+}
+
+// structDoc 按 StructDocTemplate 渲染PO结构体的文档注释
+func (p *SQLParser) structDoc() (string, error) {
+	tmpl, err := template.New("struct-doc").Parse(p.StructDocTemplate)
+	if err != nil {
+		return "", fmt.Errorf("解析结构体注释模板失败: %w", err)
+	}
+	var doc strings.Builder
+	data := map[string]string{
+		"Struct":       p.StructName,
+		"Table":        p.TableName,
+		"TableComment": p.TableComment,
+	}
+	if err := tmpl.Execute(&doc, data); err != nil {
+		return "", fmt.Errorf("渲染结构体注释模板失败: %w", err)
+	}
+	var builder strings.Builder
+	for _, line := range strings.Split(strings.TrimRight(doc.String(), "\n"), "\n") {
+		builder.WriteString(strings.TrimRight("// "+line, " ") + "\n")
+	}
+	return builder.String(), nil
+}
+
+// fieldTag 生成PO字段的结构体标签，顺序为 db、json、validate
+func (p *SQLParser) fieldTag(field FieldMeta) string {
+	var tags []string
+	if p.ORM == "gorm" {
+		tags = append(tags, gormTag(field))
+	} else {
+		dbName := field.OriginalField
+		if p.DBOmitEmpty == "nullable" && field.Nullable {
+			dbName += ",omitempty"
+		}
+		tags = append(tags, fmt.Sprintf("db:\"%s\"", dbName))
+	}
+	// BIGINT 主键在JSON中以字符串输出，避免JS客户端丢失精度
+	bigintPKString := p.BigintPKJSONString && field.IsPrimaryKey && sqlBaseType(field.SQLType) == "BIGINT"
+	if p.JSONTags || bigintPKString {
+		jsonName := field.OriginalField
+		// 主键始终序列化，其余可空字段为空时省略
+		if field.Nullable && !field.IsPrimaryKey {
+			jsonName += ",omitempty"
+		}
+		if bigintPKString {
+			jsonName += ",string"
+		}
+		tags = append(tags, fmt.Sprintf("json:\"%s\"", jsonName))
+	}
+	if p.ProtoTags {
+		tags = append(tags, protoTag(field))
+	}
+	if field.Validate != "" {
+		tags = append(tags, field.Validate)
+	}
+	tags = append(tags, p.FieldTags[strings.ToLower(field.OriginalField)]...)
+	return strings.Join(tags, " ")
+}
+
+var (
+	// fieldTagPairRegex 单个 key:"value" 标签，值中的引号需转义
+	fieldTagPairRegex = regexp.MustCompile(`\w+:"(?:[^"\\]|\\.)*"`)
+	// fieldTagRegex 一个或多个以空白分隔的 key:"value" 标签
+	fieldTagRegex = regexp.MustCompile(`^` + fieldTagPairRegex.String() + `(?:\s+` + fieldTagPairRegex.String() + `)*$`)
+)
+
+// parseFieldTag 解析 --field-tag 的 col=key:"value"，标签最终写在反引号中，不能包含反引号
+func parseFieldTag(spec string) (string, string, error) {
+	column, tag, ok := strings.Cut(spec, "=")
+	column, tag = strings.TrimSpace(column), strings.TrimSpace(tag)
+	if !ok || column == "" || !fieldTagRegex.MatchString(tag) || strings.Contains(tag, "`") {
+		return "", "", fmt.Errorf("--field-tag 格式应为 col=key:\"value\": %s", spec)
+	}
+	// 多个标签之间统一以一个空格分隔
+	return column, strings.Join(fieldTagPairRegex.FindAllString(tag, -1), " "), nil
+}
+
+// gormTag 生成 gorm:"column:id;type:bigint;primaryKey;autoIncrement" 形式的标签
+func gormTag(field FieldMeta) string {
+	sqlType := field.SQLType
+	if field.Unsigned {
+		sqlType += " unsigned"
+	}
+	parts := []string{"column:" + field.OriginalField, "type:" + strings.ToLower(sqlType)}
+	if field.IsPrimaryKey {
+		parts = append(parts, "primaryKey")
+	}
+	if field.IsAutoIncrement {
+		parts = append(parts, "autoIncrement")
+	}
+	return fmt.Sprintf("gorm:\"%s\"", strings.Join(parts, ";"))
+}
+
+// writeInsertParts 生成列名列表和对应方言的占位符，用于拼接 INSERT 语句
+func (p *SQLParser) writeInsertParts(builder *strings.Builder) {
+	var columns []string
+	for _, field := range p.Fields {
+		if field.Ignored {
+			continue
+		}
+		columns = append(columns, fmt.Sprintf("%q", field.OriginalField))
+	}
+	builder.WriteString(fmt.Sprintf("// %sColumns 表 %s 的列名，顺序与 %sPlaceholders 一致\n", p.StructName, p.TableName, p.StructName))
+	builder.WriteString(fmt.Sprintf("var %sColumns = []string{%s}\n\n", p.StructName, strings.Join(columns, ", ")))
+	builder.WriteString(fmt.Sprintf("// %sPlaceholders INSERT 语句的参数占位符\n", p.StructName))
+	builder.WriteString(fmt.Sprintf("const %sPlaceholders = %q\n\n", p.StructName, p.Dialect.placeholders(len(columns))))
+}
+
+// trimColumnName 去掉列名的统一前缀/后缀后再转换为字段名，db标签仍使用原列名
+func (p *SQLParser) trimColumnName(column string) string {
+	trimmed := strings.TrimSuffix(strings.TrimPrefix(column, p.ColumnTrimPrefix), p.ColumnTrimSuffix)
+	if trimmed == "" {
+		trimmed = column
+	}
+	if p.StripFPrefix {
+		trimmed = stripFPrefix(trimmed)
+	}
+	return trimmed
+}
+
+// writeUpsert 生成按主键冲突更新的 upsert 语句，没有主键时跳过并给出警告
+func (p *SQLParser) writeUpsert(builder *strings.Builder) {
+	var columns, conflict []string
+	for _, field := range p.Fields {
+		if field.Ignored {
+			continue
+		}
+		columns = append(columns, field.OriginalField)
+		if field.IsPrimaryKey {
+			conflict = append(conflict, field.OriginalField)
+		}
+	}
+	if len(conflict) == 0 {
+		p.Warnings = append(p.Warnings, fmt.Sprintf("表 %s 没有主键，跳过 upsert 生成", p.TableName))
+		return
+	}
+	if p.Dialect.UpsertClause == nil {
+		p.Warnings = append(p.Warnings, fmt.Sprintf("方言 %s 不支持 upsert 语句，跳过生成", p.Dialect.Name))
+		return
+	}
+	builder.WriteString(fmt.Sprintf("// %sUpsertSQL 插入 %s，主键冲突时更新其余列\n", p.StructName, p.TableName))
+	builder.WriteString(fmt.Sprintf("const %sUpsertSQL = %q\n\n", p.StructName, p.Dialect.upsertSQL(p.TableName, columns, conflict)))
+}
+
+// writeNamedArgs 生成按列名构造 sql.Named 参数的方法，
+// sql.Null* 等可空类型实现了 driver.Valuer，直接传入即可在无效时写入 NULL
+func (p *SQLParser) writeNamedArgs(builder *strings.Builder) {
+	builder.WriteString("// NamedArgs 返回以列名命名的参数，用于具名参数查询\n")
+	builder.WriteString(fmt.Sprintf("func (p %s) NamedArgs() []sql.NamedArg {\n", p.StructName))
+	builder.WriteString("\treturn []sql.NamedArg{\n")
+	for _, field := range p.Fields {
+		if field.Ignored {
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("\t\tsql.Named(%q, p.%s),\n", field.OriginalField, field.FieldName))
+	}
+	builder.WriteString("\t}\n}\n\n")
+}
+
+// nullableToBasic 可空类型及 datetime 类型对应的基础类型
+var nullableToBasic = map[string]string{
+	"sql.NullString":        "string",
+	"sql.NullInt32":         "int32",
+	"sql.NullInt64":         "int64",
+	"sql.NullFloat32":       "float32",
+	"sql.NullFloat64":       "float64",
+	"datetime.NullDateTime": "time.Time",
+	"datetime.DateTime":     "time.Time",
+	"decimal.NullDecimal":   "decimal.Decimal",
+	"sql.NullTime":          "time.Time",
+	"sql.NullInt16":         "int16",
+	"sql.NullBool":          "bool",
+	nullUint64Type:          "uint64",
+	geometryType:            "geom.T",
+	"pgtype.Text":           "string",
+	"pgtype.Int2":           "int16",
+	"pgtype.Int4":           "int32",
+	"pgtype.Int8":           "int64",
+	"pgtype.Float4":         "float32",
+	"pgtype.Float8":         "float64",
+	"pgtype.Bool":           "bool",
+	"pgtype.Date":           "time.Time",
+	"pgtype.Timestamp":      "time.Time",
+	"pgtype.Timestamptz":    "time.Time",
+}
+
+// basicType 返回PO字段类型对应的基础类型，用于entity等不需要可空包装的场景
+func basicType(fieldType string) string {
+	if basic, ok := nullableToBasic[fieldType]; ok {
+		return basic
+	}
+	if isBasicPointer(fieldType) {
+		return fieldType[1:]
+	}
+	return fieldType
+}
+
+// pointerType 把 sql.Null* 等可空包装类型换成基础类型的指针，切片、Geometry 等本身可表示 NULL 的类型保持不变
+func pointerType(goType string) string {
+	if basic, ok := nullableToBasic[goType]; ok && goType != geometryType {
+		return "*" + basic
+	}
+	return goType
+}
+
+// isBasicPointer 判断是否为指向基础类型的指针，如 --nullable-style pointer 生成的 *string
+func isBasicPointer(fieldType string) bool {
+	switch strings.TrimPrefix(fieldType, "*") {
+	case "string", "bool", "int8", "int16", "int32", "int64", "uint8", "uint16", "uint32", "uint64",
+		"float32", "float64", "time.Time", "decimal.Decimal":
+		return strings.HasPrefix(fieldType, "*")
+	}
+	return false
+}
+
+// utcSuffix 启用 --time-utc 时时间字段在转换中统一调用 .UTC()
+func (p *SQLParser) utcSuffix(field FieldMeta) string {
+	if p.TimeUTC && basicType(field.FieldType) == "time.Time" {
+		return ".UTC()"
+	}
+	return ""
+}
+
+// valueAccessor 返回从PO字段取出基础类型值的访问后缀，如 sql.NullString -> .String
+func valueAccessor(fieldType string) string {
+	switch fieldType {
+	case "sql.NullString":
+		return ".String"
+	case "datetime.NullDateTime":
+		return ".Time.Time()"
+	case "datetime.DateTime":
+		return ".Time()"
+	case "sql.NullInt32":
+		return ".Int32"
+	case "sql.NullInt64":
+		return ".Int64"
+	case "sql.NullFloat64":
+		return ".Float64"
+	case "decimal.NullDecimal":
+		return ".Decimal"
+	case "sql.NullTime":
+		return ".Time"
+	case "sql.NullInt16":
+		return ".Int16"
+	case "sql.NullBool":
+		return ".Bool"
+	case nullUint64Type:
+		return ".Uint64"
+	case geometryType:
+		return ".T"
+	case "pgtype.Text":
+		return ".String"
+	case "pgtype.Int2":
+		return ".Int16"
+	case "pgtype.Int4":
+		return ".Int32"
+	case "pgtype.Int8":
+		return ".Int64"
+	case "pgtype.Float4":
+		return ".Float32"
+	case "pgtype.Float8":
+		return ".Float64"
+	case "pgtype.Bool":
+		return ".Bool"
+	case "pgtype.Date", "pgtype.Timestamp", "pgtype.Timestamptz":
+		return ".Time"
+	}
+	return ""
+}
+
+// validCondition 返回判断可空PO字段是否有值的表达式，Geometry 没有 Valid 字段，以 T 是否为 nil 判断
+func validCondition(field FieldMeta) string {
+	if field.FieldType == geometryType {
+		return fmt.Sprintf("p.%s.T != nil", field.FieldName)
+	}
+	return fmt.Sprintf("p.%s.Valid", field.FieldName)
+}
+
+// writeScanDest 生成按列顺序返回字段指针的 scanDest 方法，可空列返回 sql.Null* 包装类型的地址
+func (p *SQLParser) writeScanDest(builder *strings.Builder) {
+	builder.WriteString("// scanDest 按列顺序返回字段指针，可直接用于 rows.Scan(p.scanDest()...)\n")
+	builder.WriteString(fmt.Sprintf("func (p *%s) scanDest() []interface{} {\n", p.StructName))
+	builder.WriteString("\treturn []interface{}{\n")
+	for _, field := range p.Fields {
+		if field.Ignored {
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("\t\t&p.%s,\n", field.FieldName))
+	}
+	builder.WriteString("\t}\n}\n\n")
+}
+
+// writeValues 生成按列顺序返回字段值的 Values 方法，与 Columns 对齐；
+// 可空类型取出基础值，无效时返回 nil 以写入 NULL
+func (p *SQLParser) writeValues(builder *strings.Builder) {
+	builder.WriteString("// Values 按列顺序返回字段值，可直接用于 db.Exec(insertSQL, p.Values()...)\n")
+	builder.WriteString(fmt.Sprintf("func (p %s) Values() []interface{} {\n", p.StructName))
+	builder.WriteString(fmt.Sprintf("\tvalues := make([]interface{}, 0, %d)\n", len(p.Fields)))
+	for _, field := range p.Fields {
+		if field.Ignored {
+			continue
+		}
+		value := "p." + field.FieldName + valueAccessor(field.FieldType) + p.utcSuffix(field)
+		if !field.Nullable || valueAccessor(field.FieldType) == "" {
+			builder.WriteString(fmt.Sprintf("\tvalues = append(values, %s)\n", value))
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("\tif %s {\n\t\tvalues = append(values, %s)\n\t} else {\n\t\tvalues = append(values, nil)\n\t}\n",
+			validCondition(field), value))
+	}
+	builder.WriteString("\treturn values\n}\n\n")
+}
+
+// isExcludedColumn 判断列是否通过 --exclude-columns 排除
+func (p *SQLParser) isExcludedColumn(column string) bool {
+	for _, excluded := range p.ExcludeColumns {
+		if strings.EqualFold(excluded, column) {
+			return true
+		}
+	}
+	return false
+}
+
+// entityReceiverPrefix 返回entity方法接收者及转换函数参数的指针前缀
+func (p *SQLParser) entityReceiverPrefix() string {
+	if p.EntityReceiver == "value" {
+		return ""
+	}
+	return "*"
+}
+
+// recordTableFiles 记录只属于某张表的生成文件，供增量生成判断文件是否还在
+func (p *SQLParser) recordTableFiles(table string, files []string) {
+	if p.TableFiles == nil {
+		p.TableFiles = make(map[string][]string)
+	}
+	p.TableFiles[table] = append(p.TableFiles[table], files...)
+}
+
+// poQualifier 返回entity文件中引用PO类型的包名前缀，PO与entity在同一个包时为空
+func (p *SQLParser) poQualifier() string {
+	if p.POPackage == p.EntityPackage {
+		return ""
+	}
+	return p.POPackage + "."
+}
+
+// privateName 返回字段首字母小写后的名称，用作entity私有字段和方法参数；与Go关键字重名时加下划线后缀
+func privateName(fieldName string) string {
+	name := lowerFirst(fieldName)
+	if token.IsKeyword(name) {
+		return name + "_"
+	}
+	return name
+}
+
+// isBaseColumn 判断字段是否属于 --extends 指定的基础结构体
+func (p *SQLParser) isBaseColumn(field FieldMeta) bool {
+	if p.ExtendsStruct == "" {
+		return false
+	}
+	for _, col := range p.ExtendsColumns {
+		if strings.EqualFold(col, field.OriginalField) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeBlankLines 把连续多个空行压缩为一个，并保证文件以单个换行结尾
+func normalizeBlankLines(code string) string {
+	code = blankLinesRegex.ReplaceAllString(code, "\n\n")
+	return strings.TrimRight(code, "\n") + "\n"
+}
+
+// goImport 返回Go类型需要导入的包，如 []sql.NullString 返回 database/sql
+func (p *SQLParser) goImport(goType string) string {
+	name, _, qualified := strings.Cut(strings.TrimLeft(goType, "*[]"), ".")
+	if !qualified {
+		return ""
+	}
+	for _, importPath := range p.ExtraImports {
+		if importName(importPath) == name {
+			return importPath
+		}
+	}
+	return knownImports[name]
+}
+
+// availableImports 返回生成代码可以引用的包，类型映射文件声明的导入优先
+func (p *SQLParser) availableImports() map[string]string {
+	imports := make(map[string]string, len(knownImports)+len(p.ExtraImports))
+	for name, importPath := range knownImports {
+		imports[name] = importPath
+	}
+	for _, importPath := range p.ExtraImports {
+		imports[importName(importPath)] = importPath
+	}
+	return imports
+}
+
+// GetOutputPaths 返回PO和entity的文件路径，如 user_po_template.go、user_entity_template.go
+func (p *SQLParser) GetOutputPaths(outputDir string) (poFile, entityFile string) {
+	return p.outputPaths(outputDir, ToSnakeCase(p.SecondStructName))
+}
+
+// outputPaths 返回以 name 为前缀的PO和entity文件路径
+func (p *SQLParser) outputPaths(outputDir, name string) (poFile, entityFile string) {
+	base := filepath.Join(outputDir, name)
+	return base + "_po" + p.OutputSuffix + ".go", base + "_entity" + p.OutputSuffix + ".go"
+}
+
+// detectImportPath 向上查找 go.mod，返回 dir 对应的包导入路径，不在模块内时返回空
+func detectImportPath(dir string) string {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for root := absDir; ; root = filepath.Dir(root) {
+		content, err := os.ReadFile(filepath.Join(root, "go.mod"))
+		if err == nil {
+			modulePath := parseModulePath(string(content))
+			if modulePath == "" {
+				return ""
+			}
+			rel, err := filepath.Rel(root, absDir)
+			if err != nil || rel == "." {
+				return modulePath
+			}
+			return modulePath + "/" + filepath.ToSlash(rel)
+		}
+		if filepath.Dir(root) == root {
+			return ""
+		}
+	}
+}
+
+// parseModulePath 从 go.mod 内容中读取 module 路径
+func parseModulePath(goMod string) string {
+	for _, line := range strings.Split(goMod, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "module") {
+			modulePath := strings.TrimSpace(strings.TrimPrefix(line, "module"))
+			return strings.Trim(modulePath, "\"`")
+		}
+	}
+	return ""
+}
+
+// parseEntityOnlyField 解析 name:type 形式的entity独有字段
+func parseEntityOnlyField(spec string) (FieldMeta, error) {
+	name, fieldType, ok := strings.Cut(spec, ":")
+	name, fieldType = strings.TrimSpace(name), strings.TrimSpace(fieldType)
+	if !ok || name == "" || fieldType == "" {
+		return FieldMeta{}, fmt.Errorf("entity独有字段格式应为 name:type: %s", spec)
+	}
+	return FieldMeta{
+		FieldName: strings.ToUpper(name[:1]) + name[1:],
+		FieldType: fieldType,
+	}, nil
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupType 优先按带参数的完整类型（如 TINYINT(1)）查找映射，找不到时使用基础类型
+func lookupType(mappings map[string]string, sqlType, params string) string {
+	if params != "" {
+		if goType, ok := mappings[sqlType+strings.ReplaceAll(params, " ", "")]; ok {
+			return goType
+		}
+	}
+	return mappings[sqlType]
+}
+
+// decimalPrecisionScale 解析 (10,2) 形式的精度和小数位，缺省的部分返回0
+func decimalPrecisionScale(params string) (int, int) {
+	parts := strings.Split(strings.Trim(params, "() "), ",")
+	precision, _ := strconv.Atoi(strings.TrimSpace(parts[0]))
+	scale := 0
+	if len(parts) > 1 {
+		scale, _ = strconv.Atoi(strings.TrimSpace(parts[1]))
+	}
+	return precision, scale
+}
+
+// normalizeTypeSpacing 去掉类型参数括号两侧的空白，如 VARCHAR ( 64 ) -> VARCHAR(64)
+func normalizeTypeSpacing(typeDef string) string {
+	typeDef = typeParenRegex.ReplaceAllString(typeDef, "(")
+	return typeCloseParenRegex.ReplaceAllString(typeDef, ")")
+}
+
+// sqlBaseType 去掉长度等参数，返回大写的SQL基础类型，如 VARCHAR(64) -> VARCHAR
+func sqlBaseType(sqlType string) string {
+	// DOUBLE PRECISION 等多词类型的空白统一为单个空格
+	return strings.ToUpper(strings.Join(strings.Fields(strings.Split(sqlType, "(")[0]), " "))
 }
 
 func ToSnakeCase(s string) string {
@@ -357,13 +2471,16 @@ func ToPascalCase(s string) string {
 	for i := range parts {
 		parts[i] = strings.Title(parts[i])
 	}
-	result := strings.Join(parts, "")
-	if len(result) > 0 && strings.HasPrefix(result, "F") {
-		remaining := result[1:]
-		if remaining != "" {
-			remaining = strings.ToUpper(string(remaining[0])) + remaining[1:]
-		}
-		return remaining
+	return strings.Join(parts, "")
+}
+
+// stripFPrefix 去掉 Fstatus、FFlag 这类列名约定中唯一的大写前缀 F 或 f_ 前缀，单独的 F、f_ 保持不变
+func stripFPrefix(column string) string {
+	if len(column) > 2 && (column[0] == 'f' || column[0] == 'F') && column[1] == '_' {
+		return column[2:]
+	}
+	if len(column) > 1 && column[0] == 'F' {
+		return column[1:]
 	}
-	return result
+	return column
 }
@@ -0,0 +1,487 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// parseFields 解析 sql，返回按列名索引的字段
+func parseFields(t *testing.T, p *SQLParser, sql string) map[string]FieldMeta {
+	t.Helper()
+	if err := p.Parse(sql); err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	fields := make(map[string]FieldMeta, len(p.Fields))
+	for _, field := range p.Fields {
+		fields[field.OriginalField] = field
+	}
+	return fields
+}
+
+func TestJSONOmitemptyOnlyForNullableNonPK(t *testing.T) {
+	p := NewSQLParser("User", "UserEntity")
+	p.JSONTags = true
+	fields := parseFields(t, p, "CREATE TABLE `t_user` (\n"+
+		"  `id` BIGINT NULL COMMENT 'ID',\n"+
+		"  `name` VARCHAR(32) NOT NULL COMMENT '名字',\n"+
+		"  `nick` VARCHAR(32) NULL COMMENT '昵称',\n"+
+		"  PRIMARY KEY (`id`)\n"+
+		");")
+	tests := []struct {
+		column string
+		want   string
+	}{
+		{"id", `json:"id"`},
+		{"name", `json:"name"`},
+		{"nick", `json:"nick,omitempty"`},
+	}
+	for _, tt := range tests {
+		if tag := p.fieldTag(fields[tt.column]); !strings.Contains(tag, tt.want) {
+			t.Errorf("%s 的标签为 %s，期望包含 %s", tt.column, tag, tt.want)
+		}
+	}
+}
+
+func TestParseShowCreateTable(t *testing.T) {
+	p := NewSQLParser("User", "UserEntity")
+	fields := parseFields(t, p, "CREATE TABLE `users` (\n"+
+		"  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT COMMENT '主键',\n"+
+		"  `name` varchar(64) COLLATE utf8mb4_bin NOT NULL DEFAULT '' COMMENT '名称',\n"+
+		"  `status` tinyint(4) NOT NULL DEFAULT '0' COMMENT '状态',\n"+
+		"  `updated_at` datetime DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',\n"+
+		"  PRIMARY KEY (`id`),\n"+
+		"  UNIQUE KEY `uk_name` (`name`)\n"+
+		") ENGINE=InnoDB AUTO_INCREMENT=1024 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin COMMENT='用户表'")
+	if p.TableName != "users" || p.TableComment != "用户表" {
+		t.Errorf("表名 %q、表注释 %q 解析错误", p.TableName, p.TableComment)
+	}
+	if len(fields) != 4 {
+		t.Fatalf("解析出 %d 列，期望 4 列: %v", len(fields), fields)
+	}
+	tests := []struct {
+		column, goType, comment string
+	}{
+		{"id", "uint64", "主键"},
+		{"name", "string", "名称"},
+		{"status", "int32", "状态"},
+		{"updated_at", "datetime.NullDateTime", "更新时间"},
+	}
+	for _, tt := range tests {
+		field := fields[tt.column]
+		if field.FieldType != tt.goType || field.Comment != tt.comment {
+			t.Errorf("%s 解析为 %s // %s，期望 %s // %s", tt.column, field.FieldType, field.Comment, tt.goType, tt.comment)
+		}
+	}
+	if !fields["id"].IsPrimaryKey || !fields["id"].IsAutoIncrement {
+		t.Error("id 应为自增主键")
+	}
+}
+
+func TestParseFractionalSecondPrecision(t *testing.T) {
+	sql := "CREATE TABLE `t_log` (\n" +
+		"  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '创建时间',\n" +
+		"  `updated_at` TIMESTAMP(6) NULL DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP(6) COMMENT '更新时间'\n" +
+		");"
+	p := NewSQLParser("Log", "LogEntity")
+	p.TimePrecisionComment = true
+	fields := parseFields(t, p, sql)
+	tests := []struct {
+		column, goType, defaultValue, comment string
+		nullable                              bool
+	}{
+		{"created_at", "datetime.DateTime", "CURRENT_TIMESTAMP(3)", "创建时间（小数秒精度: 3）", false},
+		{"updated_at", "datetime.NullDateTime", "", "更新时间（小数秒精度: 6）", true},
+	}
+	for _, tt := range tests {
+		field := fields[tt.column]
+		if field.FieldType != tt.goType || field.Nullable != tt.nullable {
+			t.Errorf("%s 解析为 %s（可空: %t），期望 %s（可空: %t）", tt.column, field.FieldType, field.Nullable, tt.goType, tt.nullable)
+		}
+		if field.DefaultValue != tt.defaultValue {
+			t.Errorf("%s 的默认值为 %q，期望 %q", tt.column, field.DefaultValue, tt.defaultValue)
+		}
+		if field.Comment != tt.comment {
+			t.Errorf("%s 的注释为 %q，期望 %q", tt.column, field.Comment, tt.comment)
+		}
+	}
+}
+
+func TestParseTypeSizeSpacing(t *testing.T) {
+	p := NewSQLParser("User", "UserEntity")
+	fields := parseFields(t, p, "CREATE TABLE `t_user` (\n"+
+		"  `name` varchar(64) NOT NULL COMMENT '名字',\n"+
+		"  `nick` VARCHAR (32) NOT NULL COMMENT '昵称',\n"+
+		"  `code` VARCHAR ( 8 ) NOT NULL COMMENT '编码'\n"+
+		");")
+	tests := []struct {
+		column, sqlType, validate string
+	}{
+		{"name", "VARCHAR(64)", "max=64"},
+		{"nick", "VARCHAR(32)", "max=32"},
+		{"code", "VARCHAR(8)", "max=8"},
+	}
+	for _, tt := range tests {
+		field, ok := fields[tt.column]
+		if !ok {
+			t.Errorf("未解析出列 %s", tt.column)
+			continue
+		}
+		if field.FieldType != "string" || !strings.EqualFold(field.SQLType, tt.sqlType) || !strings.Contains(field.Validate, tt.validate) {
+			t.Errorf("%s 解析为 %s %s %s，期望 string %s %s", tt.column, field.FieldType, field.SQLType, field.Validate, tt.sqlType, tt.validate)
+		}
+	}
+}
+
+func TestParseDecimalPrecisionScale(t *testing.T) {
+	p := NewSQLParser("Order", "OrderEntity")
+	fields := parseFields(t, p, "CREATE TABLE `t_order` (\n"+
+		"  `amount` DECIMAL(10,2) NOT NULL COMMENT '金额',\n"+
+		"  `rate` DECIMAL(5, 4) NULL COMMENT '费率',\n"+
+		"  `total` NUMERIC(20) NOT NULL COMMENT '总额',\n"+
+		"  `ratio` DECIMAL NOT NULL COMMENT '比例'\n"+
+		");")
+	tests := []struct {
+		column, goType   string
+		precision, scale int
+	}{
+		{"amount", "decimal.Decimal", 10, 2},
+		{"rate", "decimal.NullDecimal", 5, 4},
+		{"total", "decimal.Decimal", 20, 0},
+		{"ratio", "decimal.Decimal", 0, 0},
+	}
+	for _, tt := range tests {
+		field := fields[tt.column]
+		if field.FieldType != tt.goType || field.Precision != tt.precision || field.Scale != tt.scale {
+			t.Errorf("%s 解析为 %s(%d,%d)，期望 %s(%d,%d)", tt.column, field.FieldType, field.Precision, field.Scale, tt.goType, tt.precision, tt.scale)
+		}
+	}
+}
+
+func TestStripFPrefix(t *testing.T) {
+	tests := []struct {
+		column, want string
+	}{
+		{"f", "F"},
+		{"F", "F"},
+		{"fa", "Fa"},
+		{"Fstatus", "Status"},
+		{"FFlag", "Flag"},
+		{"field", "Field"},
+		{"f_amount", "Amount"},
+	}
+	p := NewSQLParser("User", "UserEntity")
+	p.StripFPrefix = true
+	for _, tt := range tests {
+		if got := ToPascalCase(p.trimColumnName(tt.column)); got != tt.want {
+			t.Errorf("%s 转换为 %s，期望 %s", tt.column, got, tt.want)
+		}
+	}
+}
+
+func TestGormTag(t *testing.T) {
+	p := NewSQLParser("User", "UserEntity")
+	p.ORM = "gorm"
+	fields := parseFields(t, p, "CREATE TABLE `t_user` (\n"+
+		"  `id` BIGINT NOT NULL AUTO_INCREMENT COMMENT 'ID',\n"+
+		"  `name` VARCHAR(32) NOT NULL COMMENT '名字',\n"+
+		"  PRIMARY KEY (`id`)\n"+
+		");")
+	tests := []struct {
+		column, want string
+	}{
+		{"id", `gorm:"column:id;type:bigint;primaryKey;autoIncrement"`},
+		{"name", `gorm:"column:name;type:varchar(32)" validate:"required,max=32"`},
+	}
+	for _, tt := range tests {
+		if tag := p.fieldTag(fields[tt.column]); tag != tt.want {
+			t.Errorf("%s 的标签为 %s，期望 %s", tt.column, tag, tt.want)
+		}
+	}
+}
+
+func TestParseIgnoresSeedData(t *testing.T) {
+	p := NewSQLParser("User", "UserEntity")
+	fields := parseFields(t, p, "INSERT INTO `t_user` (`legacy`, `name`) VALUES (1, 'a');\n"+
+		"CREATE TABLE `t_user` (\n"+
+		"  `id` BIGINT NOT NULL COMMENT 'ID',\n"+
+		"  `name` VARCHAR(32) NOT NULL COMMENT '名字'\n"+
+		");\n"+
+		"INSERT INTO `t_user` (`id`, `name`) VALUES\n"+
+		"  (1, 'root'),\n"+
+		"  (2, 'guest');\n"+
+		"UPDATE `t_user` SET `name` = 'admin' WHERE `id` = 1;\n"+
+		"DELETE FROM `t_user` WHERE `id` = 2;\n"+
+		"ALTER TABLE `t_user` ADD COLUMN `age` INT NOT NULL COMMENT '年龄';\n"+
+		"INSERT INTO `t_user` (`id`, `name`, `age`) VALUES (3, 'x', 1);")
+	if len(fields) != 3 || p.TableName != "t_user" {
+		t.Fatalf("表 %s 解析出列 %v，期望 id、name、age", p.TableName, fields)
+	}
+	for _, column := range []string{"id", "name", "age"} {
+		if _, ok := fields[column]; !ok {
+			t.Errorf("未解析出列 %s", column)
+		}
+	}
+}
+
+func TestParseIdentifierQuoting(t *testing.T) {
+	tests := []struct {
+		name, sql string
+	}{
+		{"unquoted", "CREATE TABLE t_user (\n" +
+			"  id BIGINT NOT NULL COMMENT 'ID',\n" +
+			"  name VARCHAR(32) NULL COMMENT '名字'\n" +
+			");"},
+		{"double_quoted", "CREATE TABLE \"t_user\" (\n" +
+			"  \"id\" BIGINT NOT NULL COMMENT 'ID',\n" +
+			"  \"name\" VARCHAR(32) NULL COMMENT '名字'\n" +
+			");"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewSQLParser("User", "UserEntity")
+			fields := parseFields(t, p, tt.sql)
+			if p.TableName != "t_user" || len(fields) != 2 {
+				t.Fatalf("表 %s 解析出列 %v，期望 t_user 的 id、name", p.TableName, fields)
+			}
+			if id := fields["id"]; id.FieldType != "int64" || id.Comment != "ID" {
+				t.Errorf("id 解析为 %s // %s", id.FieldType, id.Comment)
+			}
+			if name := fields["name"]; name.FieldType != "sql.NullString" || name.Comment != "名字" {
+				t.Errorf("name 解析为 %s // %s", name.FieldType, name.Comment)
+			}
+		})
+	}
+}
+
+func TestParseCompactCreateTable(t *testing.T) {
+	tests := []struct {
+		name, sql string
+	}{
+		{"no_space", "CREATE TABLE users(\n  `id` BIGINT NOT NULL COMMENT 'ID',\n  `name` VARCHAR(32) NOT NULL COMMENT '名字'\n);"},
+		{"first_column_inline", "CREATE TABLE `users` (`id` BIGINT NOT NULL COMMENT 'ID',\n  `name` VARCHAR(32) NOT NULL COMMENT '名字');"},
+		{"single_line", "CREATE TABLE IF NOT EXISTS users(id BIGINT NOT NULL COMMENT 'ID', name VARCHAR(32) NOT NULL COMMENT '名字');"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewSQLParser("User", "UserEntity")
+			fields := parseFields(t, p, tt.sql)
+			if p.TableName != "users" {
+				t.Errorf("表名解析为 %q，期望 users", p.TableName)
+			}
+			if fields["id"].FieldType != "int64" || fields["name"].FieldType != "string" || len(fields) != 2 {
+				t.Errorf("解析出列 %v，期望 id、name", fields)
+			}
+		})
+	}
+}
+
+// benchmarkSchema 生成 tables 张表、每张 columns 列的建表语句
+func benchmarkSchema(tables, columns int) string {
+	var builder strings.Builder
+	for i := 0; i < tables; i++ {
+		fmt.Fprintf(&builder, "CREATE TABLE `t_bench_%d` (\n  `id` BIGINT NOT NULL AUTO_INCREMENT COMMENT 'ID',\n", i)
+		for j := 0; j < columns; j++ {
+			fmt.Fprintf(&builder, "  `name_%d` VARCHAR(64) NULL DEFAULT NULL COMMENT '名称',\n", j)
+		}
+		builder.WriteString("  PRIMARY KEY (`id`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='压测';\n")
+	}
+	return builder.String()
+}
+
+func BenchmarkParseLargeSchema(b *testing.B) {
+	sql := benchmarkSchema(500, 10)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := NewSQLParser().Parse(sql); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseWideTable(b *testing.B) {
+	sql := benchmarkSchema(1, 2000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := NewSQLParser("Bench", "BenchEntity").Parse(sql); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestParseReader(t *testing.T) {
+	p := NewSQLParser("User", "UserEntity")
+	if err := p.ParseReader(strings.NewReader("CREATE TABLE `t_user` (\n  `id` BIGINT NOT NULL COMMENT 'ID'\n);")); err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if p.TableName != "t_user" || len(p.Fields) != 1 || p.Fields[0].FieldType != "int64" {
+		t.Errorf("表 %s 解析出列 %v，期望 t_user 的 id", p.TableName, p.Fields)
+	}
+
+	readErr := errors.New("管道已关闭")
+	if err := NewSQLParser().ParseReader(iotest.ErrReader(readErr)); !errors.Is(err, readErr) {
+		t.Errorf("读取失败时返回 %v，期望包含 %v", err, readErr)
+	}
+}
+
+func TestValidateRequired(t *testing.T) {
+	p := NewSQLParser("User", "UserEntity")
+	fields := parseFields(t, p, "CREATE TABLE `t_user` (\n"+
+		"  `id` BIGINT NOT NULL AUTO_INCREMENT COMMENT 'ID',\n"+
+		"  `age` INT NOT NULL COMMENT '年龄',\n"+
+		"  `name` VARCHAR(255) NOT NULL COMMENT '名字',\n"+
+		"  `nick` VARCHAR(32) NULL COMMENT '昵称',\n"+
+		"  `email` VARCHAR(64) NOT NULL COMMENT '邮箱 @validate:required,email',\n"+
+		"  PRIMARY KEY (`id`)\n"+
+		");")
+	tests := []struct {
+		column, want string
+	}{
+		{"id", ""},
+		{"age", `validate:"required"`},
+		{"name", `validate:"required,max=255"`},
+		{"nick", `validate:"max=32"`},
+		{"email", `validate:"required,max=64,email"`},
+	}
+	for _, tt := range tests {
+		if got := fields[tt.column].Validate; got != tt.want {
+			t.Errorf("%s 的校验标签为 %s，期望 %s", tt.column, got, tt.want)
+		}
+	}
+}
+
+func TestParseDefaultValue(t *testing.T) {
+	p := NewSQLParser("User", "UserEntity")
+	fields := parseFields(t, p, "CREATE TABLE `t_user` (\n"+
+		"  `age` INT NOT NULL DEFAULT 0 COMMENT '年龄',\n"+
+		"  `score` DECIMAL(5,2) NOT NULL DEFAULT '1.50' COMMENT '积分',\n"+
+		"  `name` VARCHAR(32) NOT NULL DEFAULT 'it''s' COMMENT '名字',\n"+
+		"  `nick` VARCHAR(32) DEFAULT NULL COMMENT '昵称',\n"+
+		"  `memo` VARCHAR(32) NULL COMMENT '备注',\n"+
+		"  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',\n"+
+		"  `uid` CHAR(36) NOT NULL DEFAULT (uuid()) COMMENT 'UID'\n"+
+		");")
+	tests := []struct {
+		column, want string
+		nullable     bool
+	}{
+		{"age", "0", false},
+		{"score", "'1.50'", false},
+		{"name", "'it''s'", false},
+		{"nick", "", true},
+		{"memo", "", true},
+		{"created_at", "CURRENT_TIMESTAMP", false},
+		{"uid", "(uuid())", false},
+	}
+	for _, tt := range tests {
+		field := fields[tt.column]
+		if field.DefaultValue != tt.want || field.Nullable != tt.nullable {
+			t.Errorf("%s 的默认值为 %q（可空: %t），期望 %q（可空: %t）", tt.column, field.DefaultValue, field.Nullable, tt.want, tt.nullable)
+		}
+	}
+}
+
+func TestFieldNameFPrefixFlag(t *testing.T) {
+	sql := "CREATE TABLE `t_order` (\n" +
+		"  `first_name` VARCHAR(32) NOT NULL COMMENT '名',\n" +
+		"  `f_amount` BIGINT NOT NULL COMMENT '金额'\n" +
+		");"
+	tests := []struct {
+		strip              bool
+		firstName, fAmount string
+	}{
+		{false, "FirstName", "FAmount"},
+		{true, "FirstName", "Amount"},
+	}
+	for _, tt := range tests {
+		p := NewSQLParser("Order", "OrderEntity")
+		p.StripFPrefix = tt.strip
+		fields := parseFields(t, p, sql)
+		if got := fields["first_name"].FieldName; got != tt.firstName {
+			t.Errorf("--strip-f-prefix=%t 时 first_name 转换为 %s，期望 %s", tt.strip, got, tt.firstName)
+		}
+		if got := fields["f_amount"].FieldName; got != tt.fAmount {
+			t.Errorf("--strip-f-prefix=%t 时 f_amount 转换为 %s，期望 %s", tt.strip, got, tt.fAmount)
+		}
+	}
+}
+
+func TestParseCreateOrReplaceTable(t *testing.T) {
+	tests := []string{
+		"CREATE OR REPLACE TABLE analytics.public.orders (\n  id NUMBER NOT NULL\n);",
+		"CREATE TRANSIENT TABLE IF NOT EXISTS public.orders (\n  id NUMBER NOT NULL\n);",
+		"CREATE OR REPLACE TEMPORARY TABLE orders(\n  id NUMBER NOT NULL\n);",
+	}
+	for _, sql := range tests {
+		p := NewSQLParser("Order", "OrderEntity")
+		fields := parseFields(t, p, sql)
+		if p.TableName != "orders" || len(fields) != 1 {
+			t.Errorf("%s\n解析为表 %q、列 %v，期望表 orders 的 id", sql, p.TableName, fields)
+		}
+	}
+}
+
+func TestHashKeySkipsNullablePK(t *testing.T) {
+	tests := []struct {
+		name, sql string
+		configure func(p *SQLParser)
+	}{
+		{"nullable_unique", "CREATE TABLE `t_device` (\n  `serial` VARCHAR(64) NULL COMMENT '序列号',\n  UNIQUE KEY `uk_serial` (`serial`)\n);",
+			func(p *SQLParser) { p.PKFromUnique = true; p.NullableStyle = "pointer" }},
+		{"pointer_override", "CREATE TABLE `t_device` (\n  `id` BIGINT NOT NULL COMMENT 'ID',\n  PRIMARY KEY (`id`)\n);",
+			func(p *SQLParser) { p.ColumnTypes = map[string]string{"id": "*int64"} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewSQLParser("Device", "DeviceEntity")
+			tt.configure(p)
+			parseFields(t, p, tt.sql)
+			var builder strings.Builder
+			p.writeHashKey(&builder)
+			if builder.Len() != 0 {
+				t.Errorf("可空主键不应生成 HashKey:\n%s", builder.String())
+			}
+			if len(p.Warnings) == 0 || !strings.Contains(p.Warnings[len(p.Warnings)-1], "HashKey") {
+				t.Errorf("缺少跳过 HashKey 的警告: %v", p.Warnings)
+			}
+		})
+	}
+}
+
+func TestGormModelRequiresCompatibleColumns(t *testing.T) {
+	sql, err := os.ReadFile("testdata/gorm.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name, sql, extends string
+	}{
+		{"compatible", string(sql), "gorm.Model"},
+		{"signed_id", strings.Replace(string(sql), "BIGINT UNSIGNED", "BIGINT", 1), ""},
+		{"nullable_created_at", strings.Replace(string(sql), "`created_at` DATETIME NOT NULL", "`created_at` DATETIME NULL", 1), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewSQLParser("Article", "ArticleEntity")
+			p.GormModel = true
+			fields := parseFields(t, p, tt.sql)
+			if p.ExtendsStruct != tt.extends {
+				t.Fatalf("ExtendsStruct 为 %q，期望 %q", p.ExtendsStruct, tt.extends)
+			}
+			if tt.extends == "" {
+				if len(p.Warnings) == 0 || !strings.Contains(p.Warnings[len(p.Warnings)-1], "gorm.Model") {
+					t.Errorf("缺少未嵌入 gorm.Model 的警告: %v", p.Warnings)
+				}
+				return
+			}
+			if got := fields["id"]; got.FieldName != "ID" || got.FieldType != "uint" {
+				t.Errorf("id 字段为 %s %s，期望 ID uint", got.FieldName, got.FieldType)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import "fmt"
+
+// pgxNullableTypes --driver pgx 时 database/sql 的可空类型对应的 pgtype 类型
+var pgxNullableTypes = map[string]string{
+	"sql.NullString":  "pgtype.Text",
+	"sql.NullInt16":   "pgtype.Int2",
+	"sql.NullInt32":   "pgtype.Int4",
+	"sql.NullInt64":   "pgtype.Int8",
+	"sql.NullFloat32": "pgtype.Float4",
+	"sql.NullFloat64": "pgtype.Float8",
+	"sql.NullBool":    "pgtype.Bool",
+}
+
+// pgxTimeTypes 可空时间列按SQL类型区分 pgtype 类型，其余映射为 sql.NullTime 的列使用 pgtype.Timestamptz
+var pgxTimeTypes = map[string]string{
+	"DATE":                        "pgtype.Date",
+	"TIME":                        "pgtype.Time",
+	"TIMESTAMP":                   "pgtype.Timestamp",
+	"TIMESTAMP WITHOUT TIME ZONE": "pgtype.Timestamp",
+	"DATETIME":                    "pgtype.Timestamp",
+	// pgtype 没有带时区的时间类型，按文本扫描
+	"TIMETZ":              "pgtype.Text",
+	"TIME WITH TIME ZONE": "pgtype.Text",
+}
+
+// SetDriver 按数据库驱动调整可空列的类型：database/sql 使用 sql.Null* 等，
+// pgx 使用 pgtype 中可表示 NULL 的类型，便于配合 pgx.RowToStructByName 扫描
+func (p *SQLParser) SetDriver(driver string) error {
+	switch driver {
+	case "database/sql":
+		return nil
+	case "pgx":
+	default:
+		return fmt.Errorf("不支持的 --driver 取值: %s", driver)
+	}
+	for sqlType, goType := range p.NullableTypeMappings {
+		if pgxType, ok := pgxNullableTypes[goType]; ok {
+			p.NullableTypeMappings[sqlType] = pgxType
+			continue
+		}
+		if goType == "sql.NullTime" {
+			if pgxType, ok := pgxTimeTypes[sqlType]; ok {
+				p.NullableTypeMappings[sqlType] = pgxType
+			} else {
+				p.NullableTypeMappings[sqlType] = "pgtype.Timestamptz"
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+// presets 常用组合的参数默认值，命令行中显式指定的参数优先
+var presets = map[string]map[string]string{
+	// sqlx：db 标签、sql.Null* 可空类型，并生成列名列表、Values 和命名参数
+	"sqlx": {
+		"gen-insert-parts": "true",
+		"gen-values":       "true",
+		"gen-namedargs":    "true",
+	},
+	// gorm：gorm 标签、json 标签，deleted_at 使用 gorm.DeletedAt 支持软删除；
+	// 列与 gorm.Model 兼容的表嵌入 gorm.Model，其余表各列仍按表结构生成
+	"gorm": {
+		"orm":         "gorm",
+		"json-tags":   "true",
+		"column-type": "deleted_at=gorm.DeletedAt",
+		"gorm-model":  "true",
+	},
+	// ent：json 标签，不生成 entitytool 的 go:generate 指令
+	"ent": {
+		"json-tags":      "true",
+		"no-go-generate": "true",
+	},
+}
+
+// presetNames 返回排序后的预设名称
+func presetNames() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applyPreset 把 --preset 对应的默认值设置到未显式指定的参数上
+func applyPreset(c *cli.Context) error {
+	name := c.String("preset")
+	if name == "" {
+		return nil
+	}
+	values, ok := presets[strings.ToLower(name)]
+	if !ok {
+		return fmt.Errorf("不支持的 --preset 取值: %s，可选值: %s", name, strings.Join(presetNames(), ", "))
+	}
+	for flag, value := range values {
+		if c.IsSet(flag) {
+			continue
+		}
+		if err := c.Set(flag, value); err != nil {
+			return fmt.Errorf("应用预设 %s 失败: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// gormModelColumns gorm.Model 的列及嵌入后对应的字段名和类型
+var gormModelColumns = []struct {
+	column, fieldName, fieldType string
+}{
+	{"id", "ID", "uint"},
+	{"created_at", "CreatedAt", "time.Time"},
+	{"updated_at", "UpdatedAt", "time.Time"},
+	{"deleted_at", "DeletedAt", "gorm.DeletedAt"},
+}
+
+// applyGormModel 表的 id 为唯一的无符号整数主键、created_at/updated_at 为非空时间、deleted_at 为可空时间时，
+// 这四列改由嵌入的 gorm.Model 提供；类型不兼容（如有符号主键）时保持按表结构生成
+func (p *SQLParser) applyGormModel() {
+	if p.ExtendsStruct != "" {
+		return
+	}
+	indexes := make(map[string]int, len(p.Fields))
+	for i, field := range p.Fields {
+		if field.IsPrimaryKey && !strings.EqualFold(field.OriginalField, "id") {
+			return
+		}
+		indexes[strings.ToLower(field.OriginalField)] = i
+	}
+	for _, column := range gormModelColumns {
+		if _, ok := indexes[column.column]; !ok {
+			return
+		}
+	}
+	for _, column := range gormModelColumns {
+		if field := p.Fields[indexes[column.column]]; field.Ignored || !gormModelCompatible(field) {
+			p.Warnings = append(p.Warnings, fmt.Sprintf("表 %s 的列 %s 与 gorm.Model 不兼容，未嵌入 gorm.Model", p.TableName, field.OriginalField))
+			return
+		}
+	}
+	p.ExtendsStruct = "gorm.Model"
+	p.ExtendsColumns = nil
+	for _, column := range gormModelColumns {
+		field := &p.Fields[indexes[column.column]]
+		field.FieldName, field.FieldType = column.fieldName, column.fieldType
+		field.GoImport = p.goImport(column.fieldType)
+		p.ExtendsColumns = append(p.ExtendsColumns, field.OriginalField)
+	}
+}
+
+// gormModelCompatible 判断列能否由 gorm.Model 中的同名字段承载
+func gormModelCompatible(field FieldMeta) bool {
+	switch strings.ToLower(field.OriginalField) {
+	case "id":
+		return field.IsPrimaryKey && !field.Nullable && strings.HasPrefix(basicType(field.FieldType), "uint")
+	case "created_at", "updated_at":
+		return !field.Nullable && basicType(field.FieldType) == "time.Time"
+	case "deleted_at":
+		return field.FieldType == "gorm.DeletedAt" || field.Nullable && basicType(field.FieldType) == "time.Time"
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// protoWireType 返回Go基础类型对应的 protobuf 编码方式
+func protoWireType(goType string) string {
+	switch goType {
+	case "bool", "int8", "int16", "int32", "int64", "uint8", "uint16", "uint32", "uint64":
+		return "varint"
+	case "float64":
+		return "fixed64"
+	case "float32":
+		return "fixed32"
+	}
+	// 字符串、字节切片以及时间、定点数等消息类型
+	return "bytes"
+}
+
+// protoTag 生成 protobuf:"varint,1,opt,name=id" 形式的标签，字段编号取列序号
+func protoTag(field FieldMeta) string {
+	goType := basicType(field.FieldType)
+	label := "opt"
+	if field.IsArray {
+		goType = strings.TrimPrefix(goType, "[]")
+		label = "rep"
+	}
+	wireType := protoWireType(goType)
+	if field.IsArray && wireType != "bytes" {
+		label += ",packed"
+	}
+	return fmt.Sprintf("protobuf:\"%s,%d,%s,name=%s\"", wireType, field.Ordinal, label, field.OriginalField)
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// writeRepoImpl 生成带 context 的仓储实现桩代码，方法签名由主键推导
+func (p *SQLParser) writeRepoImpl(builder *strings.Builder) {
+	var keyParams []string
+	for _, field := range p.Fields {
+		if field.IsPrimaryKey && !field.Ignored {
+			keyParams = append(keyParams, fmt.Sprintf("%s %s", keyParamName(field.FieldName), field.FieldType))
+		}
+	}
+	if len(keyParams) == 0 {
+		p.Warnings = append(p.Warnings, fmt.Sprintf("表 %s 没有主键，跳过仓储实现生成", p.TableName))
+		return
+	}
+
+	repo := lowerFirst(p.StructName) + "Repo"
+	keys := strings.Join(keyParams, ", ")
+
+	builder.WriteString(fmt.Sprintf("// %s 表 %s 的仓储实现\n", repo, p.TableName))
+	builder.WriteString(fmt.Sprintf("type %s struct {\n\tdb *sql.DB\n}\n\n", repo))
+	builder.WriteString(fmt.Sprintf("// New%sRepo 创建 %s 的仓储实现\n", p.StructName, p.TableName))
+	builder.WriteString(fmt.Sprintf("func New%sRepo(db *sql.DB) *%s {\n\treturn &%s{db: db}\n}\n\n", p.StructName, repo, repo))
+
+	methods := []struct {
+		doc, signature, result string
+	}{
+		{"GetByID 按主键查询", fmt.Sprintf("GetByID(ctx context.Context, %s) (*%s, error)", keys, p.StructName), "nil, "},
+		{"Create 插入一条记录", fmt.Sprintf("Create(ctx context.Context, record *%s) error", p.StructName), ""},
+		{"Update 按主键更新记录", fmt.Sprintf("Update(ctx context.Context, record *%s) error", p.StructName), ""},
+		{"Delete 按主键删除记录", fmt.Sprintf("Delete(ctx context.Context, %s) error", keys), ""},
+	}
+	for _, method := range methods {
+		builder.WriteString(fmt.Sprintf("// %s\n", method.doc))
+		builder.WriteString(fmt.Sprintf("func (r *%s) %s {\n", repo, method.signature))
+		builder.WriteString("\t// TODO: implement\n")
+		builder.WriteString(fmt.Sprintf("\treturn %serrors.New(\"%s: not implemented\")\n}\n\n",
+			method.result, strings.Fields(method.doc)[0]))
+	}
+}
+
+// repoReservedNames 仓储方法中已使用的参数、接收者和包名，主键参数不能与之重名
+var repoReservedNames = map[string]bool{"ctx": true, "r": true, "errors": true, "nil": true}
+
+// keyParamName 返回主键列作为方法参数的名称，与已使用的名称重名时加下划线后缀
+func keyParamName(fieldName string) string {
+	name := privateName(fieldName)
+	if repoReservedNames[name] {
+		return name + "_"
+	}
+	return name
+}
+
+// lowerFirst 首字母小写，开头的连续大写缩写整体小写，如 ID -> id、URLPath -> urlPath
+func lowerFirst(s string) string {
+	runes := []rune(s)
+	upper := 0
+	for upper < len(runes) && unicode.IsUpper(runes[upper]) {
+		upper++
+	}
+	// 缩写后紧跟小写字母时，最后一个大写字母属于下一个单词
+	if upper > 1 && upper < len(runes) && unicode.IsLower(runes[upper]) {
+		upper--
+	}
+	return strings.ToLower(string(runes[:upper])) + string(runes[upper:])
+}
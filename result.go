@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResultSpec --result 定义的查询结果集，Columns 为 table.col 或 col 形式的列引用
+type ResultSpec struct {
+	Name    string
+	Columns []string
+}
+
+// ResultStruct 解析完成的结果集结构体
+type ResultStruct struct {
+	Name   string
+	Fields []FieldMeta
+}
+
+// parseResultSpecs 解析多个 --result 取值。命令行框架会按逗号拆分切片参数，
+// 不含冒号的片段属于前一个结果集的列引用
+func parseResultSpecs(values []string) ([]ResultSpec, error) {
+	var specs []string
+	for _, value := range values {
+		if strings.Contains(value, ":") || len(specs) == 0 {
+			specs = append(specs, value)
+			continue
+		}
+		specs[len(specs)-1] += "," + value
+	}
+	var results []ResultSpec
+	for _, spec := range specs {
+		result, err := parseResultSpec(spec)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// parseResultSpec 解析 name:table1.col1,table2.col2 格式
+func parseResultSpec(spec string) (ResultSpec, error) {
+	name, columns, ok := strings.Cut(spec, ":")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" || strings.TrimSpace(columns) == "" {
+		return ResultSpec{}, fmt.Errorf("--result 格式应为 name:table.col,...: %s", spec)
+	}
+	result := ResultSpec{Name: name}
+	for _, column := range strings.Split(columns, ",") {
+		if column = strings.TrimSpace(column); column != "" {
+			result.Columns = append(result.Columns, column)
+		}
+	}
+	return result, nil
+}
+
+// parseTables 使用当前配置分别解析SQL中的每个 CREATE TABLE 及其 ALTER TABLE、COMMENT ON
+func (p *SQLParser) parseTables(sqlContent string) ([]*SQLParser, error) {
+	alters := tableStatements(sqlContent)
+	statements := splitCreateTables(sqlContent)
+	// 各表互不依赖，按 Concurrency 并行解析，结果仍按建表语句的顺序排列
+	tables := make([]*SQLParser, len(statements))
+	err := forEachParallel(p.Concurrency, len(statements), func(i int) error {
+		statement := statements[i]
+		table, err := p.parseTable(statement)
+		if err != nil {
+			return err
+		}
+		// 针对该表的 ALTER TABLE、COMMENT ON 与建表语句一起重新解析
+		if statements := alters[strings.ToLower(table.TableName)]; len(statements) > 0 {
+			statement += ";\n" + strings.Join(statements, "\n")
+			if table, err = p.parseTable(statement); err != nil {
+				return err
+			}
+		}
+		tables[i] = table
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
+
+// parseTable 复制当前配置解析单张表
+func (p *SQLParser) parseTable(statement string) (*SQLParser, error) {
+	table := *p
+	table.TableName, table.TableComment, table.StructName = "", "", ""
+	table.Fields, table.Warnings, table.Tables = nil, nil, nil
+	table.Results, table.ResultStructs = nil, nil
+	if err := table.Parse(statement); err != nil {
+		return nil, err
+	}
+	return &table, nil
+}
+
+// resolveResults 在所有表中查找结果集引用的列，未知或有歧义的引用给出警告并跳过
+func (p *SQLParser) resolveResults(sqlContent string) error {
+	tables := p.Tables
+	if len(tables) == 0 {
+		var err error
+		if tables, err = p.parseTables(sqlContent); err != nil {
+			return err
+		}
+	}
+	for _, spec := range p.Results {
+		type resolved struct {
+			table string
+			field FieldMeta
+		}
+		var columns []resolved
+		for _, ref := range spec.Columns {
+			tableName, column, qualified := strings.Cut(ref, ".")
+			if !qualified {
+				tableName, column = "", ref
+			}
+			var found []resolved
+			for _, table := range tables {
+				if qualified && !strings.EqualFold(table.TableName, tableName) {
+					continue
+				}
+				for _, field := range table.Fields {
+					if strings.EqualFold(field.OriginalField, column) && !field.Ignored {
+						found = append(found, resolved{table: table.TableName, field: field})
+					}
+				}
+			}
+			switch len(found) {
+			case 0:
+				p.Warnings = append(p.Warnings, fmt.Sprintf("结果集 %s 引用的列 %s 不存在，已跳过", spec.Name, ref))
+			case 1:
+				columns = append(columns, found[0])
+			default:
+				p.Warnings = append(p.Warnings, fmt.Sprintf("结果集 %s 引用的列 %s 存在于多个表中，请使用 table.col 形式", spec.Name, ref))
+			}
+		}
+
+		// 不同表的同名列以表名区分字段名和 db 标签，查询时需使用对应的别名
+		counts := make(map[string]int, len(columns))
+		for _, column := range columns {
+			counts[column.field.FieldName]++
+		}
+		result := ResultStruct{Name: spec.Name}
+		for _, column := range columns {
+			field := column.field
+			field.IsPrimaryKey, field.IsAutoIncrement = false, false
+			if counts[field.FieldName] > 1 {
+				p.Warnings = append(p.Warnings, fmt.Sprintf("结果集 %s 中的列 %s 重名，使用别名 %s_%s",
+					spec.Name, field.OriginalField, column.table, field.OriginalField))
+				field.FieldName = ToPascalCase(column.table) + field.FieldName
+				field.OriginalField = column.table + "_" + field.OriginalField
+			}
+			result.Fields = append(result.Fields, field)
+		}
+		p.ResultStructs = append(p.ResultStructs, result)
+	}
+	return nil
+}
+
+// writeResults 生成用于扫描联表查询结果的扁平结构体
+func (p *SQLParser) writeResults(builder *strings.Builder) {
+	for _, result := range p.ResultStructs {
+		builder.WriteString(fmt.Sprintf("// %s 查询结果集\n", result.Name))
+		builder.WriteString(fmt.Sprintf("type %s struct {\n", result.Name))
+		for _, field := range result.Fields {
+			builder.WriteString(fmt.Sprintf("\t%-30s %-20s `db:\"%s\"` // %s\n",
+				field.FieldName, field.FieldType, field.OriginalField, field.Comment))
+		}
+		builder.WriteString("}\n\n")
+	}
+}
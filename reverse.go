@@ -0,0 +1,224 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// reverseTypePreference 多个SQL类型映射到同一Go类型时，反向生成优先使用的SQL类型；
+// 没有列出的Go类型取按字母序第一个映射到它的SQL类型，因此 time.Time、[]byte 等需要显式指定，
+// 避免反向生成为丢失精度的 DATE、BINARY
+var reverseTypePreference = map[string]string{
+	"int32":             "INT",
+	"int64":             "BIGINT",
+	"int16":             "SMALLINT",
+	"string":            "VARCHAR",
+	"bool":              "TINYINT(1)",
+	"float64":           "DOUBLE",
+	"float32":           "FLOAT",
+	"decimal.Decimal":   "DECIMAL",
+	"datetime.DateTime": "DATETIME",
+	"time.Time":         "DATETIME",
+	"[]byte":            "BLOB",
+
+	"sql.NullInt32":         "INT",
+	"sql.NullInt64":         "BIGINT",
+	"sql.NullInt16":         "SMALLINT",
+	"sql.NullString":        "VARCHAR",
+	"sql.NullBool":          "TINYINT(1)",
+	"sql.NullFloat64":       "DOUBLE",
+	"sql.NullFloat32":       "FLOAT",
+	"decimal.NullDecimal":   "DECIMAL",
+	"datetime.NullDateTime": "DATETIME",
+	"sql.NullTime":          "DATETIME",
+}
+
+var validateMaxRegex = regexp.MustCompile(`\bmax=(\d+)`)
+
+// reverseColumn 反向生成时的列定义
+type reverseColumn struct {
+	Name          string
+	SQLType       string
+	Nullable      bool
+	PrimaryKey    bool
+	AutoIncrement bool
+	Comment       string
+}
+
+// ReverseGoFile 读取Go源文件中带 db/gorm 标签的结构体，生成 CREATE TABLE 语句。
+// structName 为空时处理文件中所有带标签的结构体
+func (p *SQLParser) ReverseGoFile(w io.Writer, fileName string, structName string) error {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, fileName, nil, parser.ParseComments)
+	if err != nil {
+		return fmt.Errorf("解析Go文件失败: %w", err)
+	}
+
+	goToSQL := p.reverseTypeMappings(p.TypeMappings)
+	nullableGoToSQL := p.reverseTypeMappings(p.NullableTypeMappings)
+
+	found := false
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok || (structName != "" && typeSpec.Name.Name != structName) {
+				continue
+			}
+
+			var columns []reverseColumn
+			for _, field := range structType.Fields.List {
+				column, ok := reverseField(field, goToSQL, nullableGoToSQL)
+				if ok {
+					columns = append(columns, column)
+				}
+			}
+			if len(columns) == 0 {
+				continue
+			}
+			found = true
+			writeCreateTable(w, ToSnakeCase(typeSpec.Name.Name), columns)
+		}
+	}
+	if !found {
+		return fmt.Errorf("未找到带 db/gorm 标签的结构体: %s", fileName)
+	}
+	return nil
+}
+
+// reverseTypeMappings 把 SQL->Go 的映射表反转为 Go->SQL
+func (p *SQLParser) reverseTypeMappings(mappings map[string]string) map[string]string {
+	reversed := make(map[string]string)
+	sqlTypes := make([]string, 0, len(mappings))
+	for sqlType := range mappings {
+		sqlTypes = append(sqlTypes, sqlType)
+	}
+	sort.Strings(sqlTypes)
+	for _, sqlType := range sqlTypes {
+		if _, exists := reversed[mappings[sqlType]]; !exists {
+			reversed[mappings[sqlType]] = sqlType
+		}
+	}
+	// 只对当前映射表会生成的Go类型使用偏好
+	for goType, sqlType := range reverseTypePreference {
+		if _, ok := reversed[goType]; ok {
+			reversed[goType] = sqlType
+		}
+	}
+	return reversed
+}
+
+// reverseField 根据字段类型和标签推导列定义
+func reverseField(field *ast.Field, goToSQL, nullableGoToSQL map[string]string) (reverseColumn, bool) {
+	if field.Tag == nil || len(field.Names) == 0 {
+		return reverseColumn{}, false
+	}
+	tagValue, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return reverseColumn{}, false
+	}
+	tag := reflect.StructTag(tagValue)
+
+	column := reverseColumn{}
+	if dbTag, ok := tag.Lookup("db"); ok {
+		column.Name = strings.Split(dbTag, ",")[0]
+	}
+	for _, option := range strings.Split(tag.Get("gorm"), ";") {
+		key, value, _ := strings.Cut(option, ":")
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "column":
+			column.Name = value
+		case "type":
+			column.SQLType = strings.ToUpper(value)
+		case "primarykey", "primary_key":
+			column.PrimaryKey = true
+		case "autoincrement", "auto_increment":
+			column.AutoIncrement = true
+		}
+	}
+	if column.Name == "" || column.Name == "-" {
+		return reverseColumn{}, false
+	}
+
+	goType := exprString(field.Type)
+	if column.SQLType == "" {
+		if sqlType, ok := goToSQL[goType]; ok {
+			column.SQLType = sqlType
+		} else if sqlType, ok := nullableGoToSQL[goType]; ok {
+			column.SQLType = sqlType
+			column.Nullable = true
+		} else {
+			column.SQLType = "TEXT"
+		}
+		if column.SQLType == "VARCHAR" || column.SQLType == "CHAR" {
+			size := "255"
+			if m := validateMaxRegex.FindStringSubmatch(tag.Get("validate")); m != nil {
+				size = m[1]
+			}
+			column.SQLType = fmt.Sprintf("%s(%s)", column.SQLType, size)
+		}
+	} else if _, ok := nullableGoToSQL[goType]; ok {
+		column.Nullable = true
+	}
+
+	if field.Comment != nil {
+		column.Comment = strings.TrimSpace(field.Comment.Text())
+	}
+	return column, true
+}
+
+// exprString 返回字段类型的源码表示，如 sql.NullString
+func exprString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		return exprString(t.X) + "." + t.Sel.Name
+	case *ast.StarExpr:
+		return "*" + exprString(t.X)
+	case *ast.ArrayType:
+		return "[]" + exprString(t.Elt)
+	}
+	return ""
+}
+
+// writeCreateTable 输出 MySQL 的 CREATE TABLE 语句
+func writeCreateTable(w io.Writer, tableName string, columns []reverseColumn) {
+	var primaryKeys []string
+	fmt.Fprintf(w, "CREATE TABLE `%s` (\n", tableName)
+	for i, column := range columns {
+		line := fmt.Sprintf("  `%s` %s", column.Name, column.SQLType)
+		if column.Nullable {
+			line += " DEFAULT NULL"
+		} else {
+			line += " NOT NULL"
+		}
+		if column.AutoIncrement {
+			line += " AUTO_INCREMENT"
+		}
+		line += fmt.Sprintf(" COMMENT '%s'", strings.ReplaceAll(column.Comment, "'", "''"))
+		if column.PrimaryKey {
+			primaryKeys = append(primaryKeys, fmt.Sprintf("`%s`", column.Name))
+		}
+		if i < len(columns)-1 || len(primaryKeys) > 0 {
+			line += ","
+		}
+		fmt.Fprintln(w, line)
+	}
+	if len(primaryKeys) > 0 {
+		fmt.Fprintf(w, "  PRIMARY KEY (%s)\n", strings.Join(primaryKeys, ","))
+	}
+	fmt.Fprintln(w, ") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// reverseSource 把 source 写入临时文件后反向生成建表语句
+func reverseSource(t *testing.T, source string) string {
+	t.Helper()
+	goFile := filepath.Join(t.TempDir(), "model.go")
+	if err := os.WriteFile(goFile, []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var out strings.Builder
+	if err := NewSQLParser().ReverseGoFile(&out, goFile, ""); err != nil {
+		t.Fatalf("反向生成失败: %v", err)
+	}
+	return out.String()
+}
+
+func TestReverseTypePreference(t *testing.T) {
+	ddl := reverseSource(t, "package model\n\n"+
+		"type Record struct {\n"+
+		"\tCreatedAt time.Time `db:\"created_at\"`\n"+
+		"\tPayload   []byte `db:\"payload\"`\n"+
+		"\tLevel     int16 `db:\"level\"`\n"+
+		"\tCount     int32 `db:\"count\"`\n"+
+		"\tName      string `db:\"name\" validate:\"max=32\"`\n"+
+		"\tEnabled   bool `db:\"enabled\"`\n"+
+		"\tBirthday  sql.NullTime `db:\"birthday\"`\n"+
+		"\tPrevLevel sql.NullInt16 `db:\"prev_level\"`\n"+
+		"}\n")
+	for _, want := range []string{
+		"`created_at` DATETIME NOT NULL",
+		"`payload` BLOB NOT NULL",
+		"`level` SMALLINT NOT NULL",
+		"`count` INT NOT NULL",
+		"`name` VARCHAR(32) NOT NULL",
+		"`enabled` TINYINT(1) NOT NULL",
+		"`birthday` DATETIME DEFAULT NULL",
+		"`prev_level` SMALLINT DEFAULT NULL",
+	} {
+		if !strings.Contains(ddl, want) {
+			t.Errorf("反向生成的建表语句缺少 %s:\n%s", want, ddl)
+		}
+	}
+}
+
+func TestReverseRoundTrip(t *testing.T) {
+	sql, err := os.ReadFile(filepath.Join("testdata", "reverse.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := runGenerate(t, string(sql), "--po", "Round", "--entity", "RoundEntity")
+	ddl := reverseSource(t, files["round_entity_po_template.go"])
+
+	want := parseFields(t, NewSQLParser("Round", "RoundEntity"), string(sql))
+	got := parseFields(t, NewSQLParser("Round", "RoundEntity"), ddl)
+	if len(got) != len(want) {
+		t.Fatalf("反向生成 %d 列，期望 %d 列:\n%s", len(got), len(want), ddl)
+	}
+	for column, field := range want {
+		reversed := got[column]
+		if sqlBaseType(reversed.SQLType) != sqlBaseType(field.SQLType) || reversed.FieldType != field.FieldType ||
+			reversed.Comment != field.Comment {
+			t.Errorf("%s 往返后为 %s %s // %s，期望 %s %s // %s", column,
+				reversed.SQLType, reversed.FieldType, reversed.Comment, field.SQLType, field.FieldType, field.Comment)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// columnDefType --gen-schema-var 使用的列定义类型，按需生成到PO文件中
+const columnDefType = "ColumnDef"
+
+// columnDefDef ColumnDef 的定义
+const columnDefDef = `// ColumnDef 建表语句中的列定义，用于在运行时比对期望的表结构与实际表结构
+type ColumnDef struct {
+	Name          string
+	SQLType       string
+	GoType        string
+	Nullable      bool
+	PrimaryKey    bool
+	AutoIncrement bool
+	Default       string
+	Comment       string
+}
+`
+
+// writeSchemaVar 生成按列顺序记录解析结果的 <结构体名>Schema 变量，被忽略的列在表中仍然存在，同样记录
+func (p *SQLParser) writeSchemaVar(builder *strings.Builder) {
+	name := p.StructName + "Schema"
+	builder.WriteString(fmt.Sprintf("// %s %s 表的列定义\n", name, p.TableName))
+	builder.WriteString(fmt.Sprintf("var %s = []%s{\n", name, columnDefType))
+	for _, field := range p.Fields {
+		builder.WriteString(fmt.Sprintf("\t{Name: %q, SQLType: %q, GoType: %q, Nullable: %t, PrimaryKey: %t, AutoIncrement: %t, Default: %q, Comment: %q},\n",
+			field.OriginalField, field.SQLType, field.FieldType, field.Nullable, field.IsPrimaryKey, field.IsAutoIncrement,
+			field.DefaultValue, field.Comment))
+	}
+	builder.WriteString("}\n\n")
+}
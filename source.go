@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// knownImports 生成代码中可能引用的包，键为代码中使用的包名
+var knownImports = map[string]string{
+	"bigquery":  "cloud.google.com/go/bigquery",
+	"big":       "math/big",
+	"binary":    "encoding/binary",
+	"civil":     "cloud.google.com/go/civil",
+	"context":   "context",
+	"datetime":  "git.woa.com/prd_base_pay_go/paycomm/datetime",
+	"decimal":   "github.com/shopspring/decimal",
+	"driver":    "database/sql/driver",
+	"errors":    "errors",
+	"fmt":       "fmt",
+	"geom":      "github.com/twpayne/go-geom",
+	"gorm":      "gorm.io/gorm",
+	"json":      "encoding/json",
+	"pgtype":    "github.com/jackc/pgx/v5/pgtype",
+	"pq":        "github.com/lib/pq",
+	"rand":      "math/rand",
+	"sql":       "database/sql",
+	"strconv":   "strconv",
+	"strings":   "strings",
+	"time":      "time",
+	"uuid":      "github.com/google/uuid",
+	"validator": "github.com/go-playground/validator/v10",
+	"wkb":       "github.com/twpayne/go-geom/encoding/wkb",
+}
+
+// majorVersionRegex 导入路径末尾的主版本号，如 /v10
+var majorVersionRegex = regexp.MustCompile(`^v\d+$`)
+
+// importName 返回导入路径默认的包名，忽略末尾的主版本号
+func importName(importPath string) string {
+	name := path.Base(importPath)
+	if majorVersionRegex.MatchString(name) {
+		name = path.Base(path.Dir(importPath))
+	}
+	return name
+}
+
+// sourceWriter 把生成的代码逐段格式化后写入文件，大量表时不必在内存中保留整个文件。
+// 代码先写入临时文件，Close 时按实际引用的包生成 import 后再拼接为最终文件
+type sourceWriter struct {
+	fileName  string
+	pkg       string
+	imports   map[string]string
+	used      map[string]bool
+	body      *os.File
+	w         *bufio.Writer
+	written   bool
+	err       error
+	formatErr error
+	closed    bool
+}
+
+// newSourceWriter 准备写入包名为 pkg 的 fileName，imports 为可导入的包名到导入路径的映射
+func newSourceWriter(fileName, pkg string, imports map[string]string) (*sourceWriter, error) {
+	body, err := os.CreateTemp(filepath.Dir(fileName), "."+filepath.Base(fileName)+".*")
+	if err != nil {
+		return nil, fmt.Errorf("写入文件失败: %w", err)
+	}
+	return &sourceWriter{
+		fileName: fileName,
+		pkg:      pkg,
+		imports:  imports,
+		used:     make(map[string]bool),
+		body:     body,
+		w:        bufio.NewWriter(body),
+	}, nil
+}
+
+// WriteChunk 格式化并写入一段完整的声明，段与段之间以空行分隔；
+// 格式化失败时错误在 Close 时返回，不写出文件
+func (s *sourceWriter) WriteChunk(code string) {
+	s.WriteFormatted(formatSource(code))
+}
+
+// WriteFormatted 写入已由 formatSource 格式化的一段代码，便于在其他协程中提前格式化
+func (s *sourceWriter) WriteFormatted(formatted string, err error) {
+	if err != nil && s.formatErr == nil {
+		s.formatErr = err
+	}
+	for _, name := range referencedPackages(formatted) {
+		s.used[name] = true
+	}
+	if s.err != nil {
+		return
+	}
+	if s.written {
+		_, s.err = s.w.WriteString("\n")
+	}
+	if s.err == nil {
+		_, s.err = s.w.WriteString(formatted)
+	}
+	s.written = true
+}
+
+// Close 写出 package、import 和全部代码并删除临时文件，优先返回写入错误；
+// 有代码格式化失败时不写出文件，返回格式化错误。重复调用时直接返回 nil
+func (s *sourceWriter) Close() error {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	defer os.Remove(s.body.Name())
+	err := s.err
+	if err == nil {
+		err = s.w.Flush()
+	}
+	if err == nil && s.formatErr == nil {
+		err = s.writeFile()
+	}
+	if closeErr := s.body.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("写入文件失败: %w", err)
+	}
+	if s.formatErr != nil {
+		return fmt.Errorf("%s: %w", s.fileName, s.formatErr)
+	}
+	return nil
+}
+
+// writeFile 生成文件头并拷贝临时文件中的代码
+func (s *sourceWriter) writeFile() error {
+	paths := make(map[string]bool)
+	for name := range s.used {
+		if importPath, ok := s.imports[name]; ok {
+			paths[importPath] = true
+		}
+	}
+	// 标准库在前，第三方包在后，两组之间空一行
+	var std, thirdParty []string
+	for importPath := range paths {
+		if strings.Contains(strings.SplitN(importPath, "/", 2)[0], ".") {
+			thirdParty = append(thirdParty, importPath)
+		} else {
+			std = append(std, importPath)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(thirdParty)
+
+	var header strings.Builder
+	header.WriteString(fmt.Sprintf("package %s\n", s.pkg))
+	if len(paths) > 0 {
+		header.WriteString("\nimport (\n")
+		for i, group := range [][]string{std, thirdParty} {
+			if i > 0 && len(std) > 0 && len(group) > 0 {
+				header.WriteString("\n")
+			}
+			for _, importPath := range group {
+				header.WriteString(fmt.Sprintf("\t\"%s\"\n", importPath))
+			}
+		}
+		header.WriteString(")\n")
+	}
+	if s.written {
+		header.WriteString("\n")
+	}
+
+	file, err := os.Create(s.fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if _, err := file.WriteString(header.String()); err != nil {
+		return err
+	}
+	if _, err := s.body.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, s.body); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// referencedPackages 返回代码中以 pkg.Name 形式引用、且不是局部变量的包名，代码无法解析时返回空
+func referencedPackages(code string) []string {
+	file, err := parser.ParseFile(token.NewFileSet(), "", "package p\n"+code, 0)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			// 未解析到声明的标识符即为包名，接收者、参数等局部变量都有对应的声明
+			if ident, ok := sel.X.(*ast.Ident); ok && ident.Obj == nil {
+				names = append(names, ident.Name)
+			}
+		}
+		return true
+	})
+	return names
+}
+
+// formatSource 按 gofmt 格式化生成的代码，可以是完整文件也可以是若干声明，
+// 失败说明生成的代码有语法错误，此时返回未格式化的代码和错误
+func formatSource(code string) (string, error) {
+	code = normalizeBlankLines(code)
+	formatted, err := format.Source([]byte(code))
+	if err != nil {
+		return code, fmt.Errorf("格式化生成代码失败: %w", err)
+	}
+	return string(formatted), nil
+}
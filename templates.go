@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// templateData 传给 --templates-dir 模板的数据，字段与 inspect --json 的输出一致，另加 entity 结构体名和包名
+type templateData struct {
+	tableSchema
+	Entity        string
+	Package       string
+	EntityPackage string
+}
+
+// templateFuncs 模板中可用的辅助函数
+var templateFuncs = template.FuncMap{
+	"lowerFirst": lowerFirst,
+	"snake":      ToSnakeCase,
+	"pascal":     ToPascalCase,
+	"basicType":  basicType,
+	"join":       strings.Join,
+}
+
+// GenerateFromTemplates 用 dir 下的每个 *.tmpl 为每张表生成一个文件，
+// 文件名为 <entity结构体名>_<模板名>.go，返回生成的文件路径
+func (p *SQLParser) GenerateFromTemplates(dir, outputDir string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return nil, fmt.Errorf("查找模板失败: %w", err)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("模板目录 %s 下没有 .tmpl 文件", dir)
+	}
+	sort.Strings(paths)
+
+	tables := p.Tables
+	if len(tables) == 0 {
+		tables = []*SQLParser{p}
+	}
+	var files []string
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("读取模板失败: %w", err)
+		}
+		name := strings.TrimSuffix(filepath.Base(path), ".tmpl")
+		tmpl, err := template.New(name).Funcs(templateFuncs).Parse(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("解析模板 %s 失败: %w", path, err)
+		}
+		for _, table := range tables {
+			key := strings.ToLower(table.TableName)
+			if p.UnchangedTables[key] {
+				continue
+			}
+			var builder strings.Builder
+			data := templateData{
+				tableSchema:   table.schema(),
+				Entity:        table.SecondStructName,
+				Package:       table.POPackage,
+				EntityPackage: table.EntityPackage,
+			}
+			if err := tmpl.Execute(&builder, data); err != nil {
+				return nil, fmt.Errorf("渲染模板 %s 失败: %w", path, err)
+			}
+			fileName := filepath.Join(outputDir, ToSnakeCase(table.SecondStructName)+"_"+name+".go")
+			if err := os.WriteFile(fileName, []byte(builder.String()), 0644); err != nil {
+				return nil, fmt.Errorf("写入文件失败: %w", err)
+			}
+			p.recordTableFiles(key, []string{fileName})
+			files = append(files, fileName)
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,14 @@
+CREATE TABLE `t_user` (
+  `id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '主键',
+  `name` VARCHAR(64) NOT NULL COMMENT '用户名',
+  `nick` VARCHAR(32) DEFAULT NULL COMMENT '昵称',
+  `age` INT NOT NULL COMMENT '年龄',
+  `score` INT DEFAULT NULL COMMENT '积分',
+  `balance` DECIMAL(10,2) NOT NULL COMMENT '余额',
+  `rate` DECIMAL(5,4) DEFAULT NULL COMMENT '费率',
+  `enabled` TINYINT(1) NOT NULL COMMENT '是否启用',
+  `birthday` DATE DEFAULT NULL COMMENT '生日',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_at` DATETIME DEFAULT NULL COMMENT '更新时间',
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB COMMENT='用户';
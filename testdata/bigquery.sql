@@ -0,0 +1,13 @@
+CREATE TABLE `proj.ds.events` (
+  `id` INT64 NOT NULL,
+  `name` STRING NOT NULL,
+  `label` STRING,
+  `score` FLOAT64,
+  `active` BOOL NOT NULL,
+  `flagged` BOOL,
+  `payload` BYTES,
+  `created_at` TIMESTAMP NOT NULL,
+  `deleted_at` TIMESTAMP,
+  `day` DATE NOT NULL,
+  `last_day` DATE
+);
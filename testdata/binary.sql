@@ -0,0 +1,9 @@
+CREATE TABLE `t_file` (
+  `id` BINARY(16) NOT NULL COMMENT 'UUID',
+  `digest` VARBINARY(255) NOT NULL COMMENT '摘要',
+  `thumb` TINYBLOB NULL COMMENT '缩略图',
+  `content` BLOB NOT NULL COMMENT '内容',
+  `preview` MEDIUMBLOB NULL COMMENT '预览',
+  `raw` LONGBLOB NULL COMMENT '原始数据',
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='文件';
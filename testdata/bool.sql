@@ -0,0 +1,9 @@
+CREATE TABLE `t_flag` (
+  `id` BIGINT NOT NULL AUTO_INCREMENT COMMENT 'ID',
+  `is_deleted` TINYINT(1) NOT NULL DEFAULT 0 COMMENT '是否删除',
+  `is_active` TINYINT(1) NULL COMMENT '是否启用',
+  `enabled` BOOLEAN NOT NULL COMMENT '开关',
+  `visible` BOOL NULL COMMENT '是否可见',
+  `status` TINYINT(4) NOT NULL COMMENT '状态',
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='标记';
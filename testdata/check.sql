@@ -0,0 +1,7 @@
+CREATE TABLE `t_person` (
+  `id` BIGINT NOT NULL AUTO_INCREMENT COMMENT 'ID',
+  `age` INT NOT NULL CHECK (age BETWEEN 0 AND 150) COMMENT '年龄',
+  `score` INT NULL DEFAULT NULL CHECK (`score` BETWEEN 1 AND 100) COMMENT '分数',
+  `level` TINYINT NOT NULL DEFAULT 1 CHECK (level >= 1) COMMENT '等级',
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='人员';
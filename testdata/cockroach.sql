@@ -0,0 +1,8 @@
+CREATE TABLE accounts (
+  id INT PRIMARY KEY DEFAULT unique_rowid(),
+  owner STRING NOT NULL,
+  nickname STRING,
+  token UUID,
+  opened_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  closed_at TIMESTAMPTZ
+);
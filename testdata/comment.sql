@@ -0,0 +1,6 @@
+CREATE TABLE `t_note` (
+  `id` BIGINT NOT NULL AUTO_INCREMENT,
+  `title` VARCHAR(64) NOT NULL COMMENT 'title shown in the list view, trimmed to sixty four characters',
+  `body` TEXT NULL,
+  PRIMARY KEY (`id`)
+);
@@ -0,0 +1,7 @@
+CREATE TABLE `t_member` (
+  `tenant_id` BIGINT NOT NULL COMMENT '租户',
+  `user_id` BIGINT NOT NULL COMMENT '用户',
+  `code` VARCHAR(32) NOT NULL COMMENT '编码',
+  PRIMARY KEY `pk_member` (`tenant_id`, `user_id`),
+  KEY `idx_code` (`code`(8))
+);
@@ -0,0 +1,5 @@
+CREATE TABLE `proj.ds.ledger` (
+  `id` INT64 NOT NULL,
+  `amount` NUMERIC NOT NULL,
+  `fee` NUMERIC
+);
@@ -0,0 +1,8 @@
+CREATE TABLE document (
+  id BIGINT PRIMARY KEY,
+  tags TEXT[] NOT NULL,
+  scores INT[],
+  blobs BYTEA[],
+  body BYTEA,
+  parent_id BIGINT
+);
@@ -0,0 +1,9 @@
+CREATE TABLE events (
+  id BIGINT PRIMARY KEY,
+  name VARCHAR(64) NOT NULL,
+  note VARCHAR(255),
+  total HUGEINT,
+  hits UINTEGER,
+  score DOUBLE,
+  happened_at TIMESTAMP
+);
@@ -0,0 +1,5 @@
+CREATE TABLE `t_shipment` (
+  `order_no` VARCHAR(32) NOT NULL COMMENT '订单号',
+  `carrier` VARCHAR(16) NOT NULL COMMENT '承运商',
+  PRIMARY KEY (`order_no`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='发货';
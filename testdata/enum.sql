@@ -0,0 +1,6 @@
+CREATE TABLE `t_account` (
+  `id` BIGINT NOT NULL AUTO_INCREMENT COMMENT 'ID',
+  `status` ENUM('active','inactive','banned') NOT NULL COMMENT '状态',
+  `level` TINYINT NOT NULL DEFAULT 0 COMMENT '等级 0:普通 1:会员 2:超级会员',
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='账户';
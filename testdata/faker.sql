@@ -0,0 +1,6 @@
+CREATE TABLE `t_visit` (
+  `id` BIGINT NOT NULL AUTO_INCREMENT COMMENT 'ID',
+  `page` VARCHAR(64) NULL COMMENT '页面',
+  `left_at` DATETIME NULL COMMENT '离开时间',
+  PRIMARY KEY (`id`)
+);
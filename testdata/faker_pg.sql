@@ -0,0 +1,5 @@
+CREATE TABLE visit (
+  id BIGSERIAL PRIMARY KEY,
+  page VARCHAR(64),
+  left_at TIMESTAMP
+);
@@ -0,0 +1,7 @@
+CREATE TABLE `t_place` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `loc` point NOT NULL SRID 4326 COMMENT '位置',
+  `area` polygon DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  SPATIAL KEY `idx_loc` (`loc`)
+) ENGINE=InnoDB;
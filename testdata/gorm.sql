@@ -0,0 +1,9 @@
+CREATE TABLE `t_article` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID',
+  `title` VARCHAR(128) NOT NULL COMMENT '标题',
+  `body` TEXT NULL COMMENT '正文',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_at` DATETIME NOT NULL COMMENT '更新时间',
+  `deleted_at` DATETIME NULL COMMENT '删除时间',
+  PRIMARY KEY (`id`)
+) COMMENT='文章';
@@ -0,0 +1,6 @@
+CREATE TABLE `t_kind` (
+  `type` INT NOT NULL,
+  `func` VARCHAR(8) NOT NULL,
+  `name` VARCHAR(8) NOT NULL,
+  PRIMARY KEY (`type`,`func`)
+);
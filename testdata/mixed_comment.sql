@@ -0,0 +1,11 @@
+CREATE TABLE `t_customer` (
+  `id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '客户ID',
+  `name` VARCHAR(64) NOT NULL,
+  `email` VARCHAR(128) NULL COMMENT '邮箱',
+  `phone` VARCHAR(32) NULL,
+  `level` INT NOT NULL DEFAULT 0 COMMENT '等级',
+  `remark` TEXT NULL,
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_at` DATETIME NOT NULL,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB COMMENT='客户';
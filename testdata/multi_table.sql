@@ -0,0 +1,16 @@
+CREATE TABLE `users` (
+  `id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '用户ID',
+  `name` VARCHAR(32) NOT NULL COMMENT '名字',
+  `deleted` DATETIME NULL COMMENT '删除',
+  PRIMARY KEY (`id`)
+) COMMENT='用户';
+CREATE TABLE `orders` (
+  `id` BIGINT NOT NULL COMMENT '订单ID',
+  `user_id` BIGINT NOT NULL COMMENT '用户',
+  `name` VARCHAR(64) NULL COMMENT '订单名'
+);
+CREATE TABLE `t_tags` (
+  `id` INT NOT NULL COMMENT 'ID',
+  `tag` VARCHAR(16) NOT NULL COMMENT '标签'
+);
+ALTER TABLE `orders` ADD COLUMN `amount` DECIMAL(10,2) NOT NULL COMMENT '金额' AFTER `user_id`;
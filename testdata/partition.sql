@@ -0,0 +1,12 @@
+CREATE TABLE `t_log` (
+  `id` BIGINT NOT NULL AUTO_INCREMENT COMMENT 'ID',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  PRIMARY KEY (`id`, `created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='日志'
+PARTITION BY RANGE (YEAR(created_at)) (
+  PARTITION `p2023` VALUES LESS THAN (2024) COMMENT '2023年',
+  PARTITION `p2024` VALUES LESS THAN MAXVALUE COMMENT 'rest'
+);
+CREATE TABLE `t2` (
+  `id` INT NOT NULL COMMENT 'x'
+) /*!50100 PARTITION BY HASH (`id`) PARTITIONS 4 */;
@@ -0,0 +1,15 @@
+CREATE TABLE public.accounts (
+    id BIGSERIAL PRIMARY KEY,
+    seq SERIAL NOT NULL,
+    uid UUID NOT NULL,
+    email TEXT NOT NULL,
+    nickname VARCHAR(32),
+    active BOOLEAN NOT NULL DEFAULT true,
+    profile JSONB,
+    avatar BYTEA,
+    login_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    deleted_at TIMESTAMPTZ
+);
+COMMENT ON TABLE public.accounts IS '账户';
+COMMENT ON COLUMN public.accounts.email IS '邮箱';
@@ -0,0 +1,12 @@
+CREATE TABLE `t_task` (
+  `id` BIGINT NOT NULL AUTO_INCREMENT,
+  `title` VARCHAR(64) NOT NULL,
+  `retries` INT NOT NULL DEFAULT 0,
+  `state` TINYINT NOT NULL DEFAULT '0',
+  `owner` VARCHAR(32) NOT NULL DEFAULT '',
+  `priority` INT NOT NULL DEFAULT 1,
+  `queue` VARCHAR(32) NOT NULL DEFAULT 'default',
+  `note` VARCHAR(255) NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`)
+);
@@ -0,0 +1,15 @@
+CREATE TABLE `t_round` (
+  `id` BIGINT NOT NULL AUTO_INCREMENT COMMENT 'ID',
+  `name` VARCHAR(64) NOT NULL COMMENT '名称',
+  `nick` VARCHAR(32) NULL COMMENT '昵称',
+  `age` INT NOT NULL COMMENT '年龄',
+  `score` INT NULL COMMENT '积分',
+  `enabled` TINYINT(1) NOT NULL COMMENT '是否启用',
+  `rate` DOUBLE NULL COMMENT '比率',
+  `weight` FLOAT NOT NULL COMMENT '重量',
+  `balance` DECIMAL(10,2) NOT NULL COMMENT '余额',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_at` DATETIME NULL COMMENT '更新时间',
+  `avatar` BLOB NOT NULL COMMENT '头像',
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='往返';
@@ -0,0 +1,12 @@
+CREATE OR REPLACE TABLE analytics.public.orders (
+  id NUMBER(38,0) NOT NULL PRIMARY KEY,
+  customer VARCHAR(100) NOT NULL,
+  name VARCHAR(100),
+  amount NUMBER(10,2) NOT NULL,
+  discount NUMBER(10,2),
+  quantity INTEGER,
+  paid BOOLEAN,
+  attrs VARIANT,
+  ordered_at TIMESTAMP_NTZ NOT NULL,
+  shipped_at TIMESTAMP_LTZ
+);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS notes (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  title TEXT NOT NULL,
+  score REAL,
+  price NUMERIC NOT NULL,
+  payload BLOB,
+  pinned BOOLEAN NOT NULL DEFAULT 0,
+  tags VARCHAR(64),
+  custom_kind MEDIUMTEXT,
+  created_at DATETIME NOT NULL
+);
@@ -0,0 +1,58 @@
+// Package bigquery 测试用的桩代码，只声明生成代码引用到的部分
+package bigquery
+
+import (
+	"time"
+
+	"cloud.google.com/go/civil"
+)
+
+type NullString struct {
+	StringVal string
+	Valid     bool
+}
+
+type NullInt64 struct {
+	Int64 int64
+	Valid bool
+}
+
+type NullFloat64 struct {
+	Float64 float64
+	Valid   bool
+}
+
+type NullBool struct {
+	Bool  bool
+	Valid bool
+}
+
+type NullTimestamp struct {
+	Timestamp time.Time
+	Valid     bool
+}
+
+type NullDate struct {
+	Date  civil.Date
+	Valid bool
+}
+
+type NullDateTime struct {
+	DateTime civil.DateTime
+	Valid    bool
+}
+
+type NullTime struct {
+	Time  civil.Time
+	Valid bool
+}
+
+type NullJSON struct {
+	JSONVal string
+	Valid   bool
+}
+
+type NullGeography struct {
+	GeographyVal string
+	Valid        bool
+}
@@ -0,0 +1,17 @@
+// Package civil 测试用的桩代码，只声明生成代码引用到的部分
+package civil
+
+type Date struct {
+	Year  int
+	Month int
+	Day   int
+}
+
+type Time struct {
+	Hour, Minute, Second, Nanosecond int
+}
+
+type DateTime struct {
+	Date Date
+	Time Time
+}
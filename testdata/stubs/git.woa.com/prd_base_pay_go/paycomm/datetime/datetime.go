@@ -0,0 +1,15 @@
+// Package datetime 测试用的桩代码，只声明生成代码引用到的部分
+package datetime
+
+import "time"
+
+type DateTime struct{ t time.Time }
+
+func NewDateTime(t time.Time) DateTime { return DateTime{t: t} }
+
+func (d DateTime) Time() time.Time { return d.t }
+
+type NullDateTime struct {
+	Time  DateTime
+	Valid bool
+}
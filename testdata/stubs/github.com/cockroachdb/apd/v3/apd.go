@@ -0,0 +1,9 @@
+// Package apd 测试用的桩代码，只声明生成代码引用到的部分
+package apd
+
+type Decimal struct{}
+
+type NullDecimal struct {
+	Decimal Decimal
+	Valid   bool
+}
@@ -0,0 +1,11 @@
+// Package uuid 测试用的桩代码，只声明生成代码引用到的部分
+package uuid
+
+type UUID [16]byte
+
+func (u UUID) String() string { return "" }
+
+type NullUUID struct {
+	UUID  UUID
+	Valid bool
+}
@@ -0,0 +1,64 @@
+// Package pgtype 测试用的桩代码，只声明生成代码引用到的部分
+package pgtype
+
+import "time"
+
+type InfinityModifier int8
+
+type Text struct {
+	String string
+	Valid  bool
+}
+
+type Int2 struct {
+	Int16 int16
+	Valid bool
+}
+
+type Int4 struct {
+	Int32 int32
+	Valid bool
+}
+
+type Int8 struct {
+	Int64 int64
+	Valid bool
+}
+
+type Float4 struct {
+	Float32 float32
+	Valid   bool
+}
+
+type Float8 struct {
+	Float64 float64
+	Valid   bool
+}
+
+type Bool struct {
+	Bool  bool
+	Valid bool
+}
+
+type Date struct {
+	Time             time.Time
+	InfinityModifier InfinityModifier
+	Valid            bool
+}
+
+type Timestamp struct {
+	Time             time.Time
+	InfinityModifier InfinityModifier
+	Valid            bool
+}
+
+type Timestamptz struct {
+	Time             time.Time
+	InfinityModifier InfinityModifier
+	Valid            bool
+}
+
+type Time struct {
+	Microseconds int64
+	Valid        bool
+}
@@ -0,0 +1,12 @@
+// Package pq 测试用的桩代码，只声明生成代码引用到的部分
+package pq
+
+type (
+	BoolArray    []bool
+	ByteaArray   [][]byte
+	Float64Array []float64
+	Float32Array []float32
+	Int64Array   []int64
+	Int32Array   []int32
+	StringArray  []string
+)
@@ -0,0 +1,18 @@
+// Package decimal 测试用的桩代码，只声明生成代码引用到的部分
+package decimal
+
+type Decimal struct {
+	value int64
+	exp   int32
+}
+
+func New(value int64, exp int32) Decimal { return Decimal{value: value, exp: exp} }
+
+func (d Decimal) String() string { return "" }
+
+func (d Decimal) Equal(d2 Decimal) bool { return d == d2 }
+
+type NullDecimal struct {
+	Decimal Decimal
+	Valid   bool
+}
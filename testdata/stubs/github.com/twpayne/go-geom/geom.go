@@ -0,0 +1,38 @@
+// Package geom 测试用的桩代码，只声明生成代码引用到的部分
+package geom
+
+type T interface {
+	SRID() int
+}
+
+type geom struct{ srid int }
+
+func (g *geom) SRID() int { return g.srid }
+
+type Point struct{ geom }
+
+func (g *Point) SetSRID(srid int) *Point { g.srid = srid; return g }
+
+type LineString struct{ geom }
+
+func (g *LineString) SetSRID(srid int) *LineString { g.srid = srid; return g }
+
+type Polygon struct{ geom }
+
+func (g *Polygon) SetSRID(srid int) *Polygon { g.srid = srid; return g }
+
+type MultiPoint struct{ geom }
+
+func (g *MultiPoint) SetSRID(srid int) *MultiPoint { g.srid = srid; return g }
+
+type MultiLineString struct{ geom }
+
+func (g *MultiLineString) SetSRID(srid int) *MultiLineString { g.srid = srid; return g }
+
+type MultiPolygon struct{ geom }
+
+func (g *MultiPolygon) SetSRID(srid int) *MultiPolygon { g.srid = srid; return g }
+
+type GeometryCollection struct{ geom }
+
+func (g *GeometryCollection) SetSRID(srid int) *GeometryCollection { g.srid = srid; return g }
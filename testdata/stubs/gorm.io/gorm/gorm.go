@@ -0,0 +1,16 @@
+// Package gorm 测试用的桩代码，只声明生成代码引用到的部分
+package gorm
+
+import (
+	"database/sql"
+	"time"
+)
+
+type DeletedAt sql.NullTime
+
+type Model struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt DeletedAt `gorm:"index"`
+}
@@ -0,0 +1,7 @@
+CREATE TABLE memberships (
+  org_id BIGINT,
+  user_id BIGINT,
+  role TEXT,
+  joined_at TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY (org_id, user_id)
+);
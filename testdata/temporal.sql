@@ -0,0 +1,12 @@
+CREATE TABLE `t_schedule` (
+  `id` BIGINT NOT NULL AUTO_INCREMENT COMMENT 'ID',
+  `created_at` DATETIME NOT NULL COMMENT '创建时间',
+  `updated_at` TIMESTAMP NULL COMMENT '更新时间',
+  `start_date` DATE NOT NULL COMMENT '开始日期',
+  `end_date` DATE NULL COMMENT '结束日期',
+  `start_time` TIME(6) NOT NULL COMMENT '开始时间',
+  `end_time` TIME NULL COMMENT '结束时间',
+  `year` YEAR NOT NULL COMMENT '年份',
+  `prev_year` YEAR NULL COMMENT '上一年',
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='排期';
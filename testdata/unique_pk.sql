@@ -0,0 +1,5 @@
+CREATE TABLE `t_device` (
+  `serial` VARCHAR(64) NOT NULL COMMENT '序列号',
+  `name` VARCHAR(32) NOT NULL COMMENT '名称',
+  UNIQUE KEY `uk_serial` (`serial`)
+);
@@ -0,0 +1,9 @@
+CREATE TABLE `t_counter` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID',
+  `hits` INT UNSIGNED NOT NULL COMMENT '点击数',
+  `level` TINYINT UNSIGNED NOT NULL COMMENT '等级',
+  `views` INT(10) UNSIGNED NULL COMMENT '浏览数',
+  `total` BIGINT UNSIGNED NULL COMMENT '总数',
+  `delta` INT NOT NULL COMMENT '增量',
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='计数器';
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// 类型映射按以下顺序逐层覆盖，后者优先：
+//  1. 方言默认映射（--dialect）
+//  2. 类型映射文件（--type-map）
+//  3. 按列覆盖（--column-type col=type）
+//  4. 列注释中的 @type: 指令
+
+// TypeMapConfig 类型映射文件的内容，键为SQL基础类型，Imports 为映射后的类型需要的导入路径
+type TypeMapConfig struct {
+	Types         map[string]string `json:"types"`
+	NullableTypes map[string]string `json:"nullable_types"`
+	Imports       []string          `json:"imports"`
+}
+
+// LoadTypeMapFile 读取 JSON 格式的类型映射文件
+func LoadTypeMapFile(path string) (*TypeMapConfig, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取类型映射文件失败: %w", err)
+	}
+	var config TypeMapConfig
+	if err := json.Unmarshal(content, &config); err != nil {
+		return nil, fmt.Errorf("解析类型映射文件失败: %w", err)
+	}
+	return &config, nil
+}
+
+// ApplyTypeMap 把类型映射文件合并到当前映射之上，应在 SetDialect 之后调用
+func (p *SQLParser) ApplyTypeMap(config *TypeMapConfig) {
+	for sqlType, goType := range config.Types {
+		p.TypeMappings[strings.ToUpper(sqlType)] = goType
+	}
+	for sqlType, goType := range config.NullableTypes {
+		p.NullableTypeMappings[strings.ToUpper(sqlType)] = goType
+	}
+	for _, path := range config.Imports {
+		if !containsString(p.ExtraImports, path) {
+			p.ExtraImports = append(p.ExtraImports, path)
+		}
+	}
+}
+
+// parseColumnType 解析 --column-type 的 col=type 格式
+func parseColumnType(spec string) (string, string, error) {
+	column, goType, ok := strings.Cut(spec, "=")
+	column, goType = strings.TrimSpace(column), strings.TrimSpace(goType)
+	if !ok || column == "" || goType == "" {
+		return "", "", fmt.Errorf("--column-type 格式应为 col=type: %s", spec)
+	}
+	return column, goType, nil
+}
+
+// columnType 返回列的显式类型覆盖，注释指令优先于 --column-type
+func (p *SQLParser) columnType(column string, directives map[string]string) (string, bool) {
+	if goType, ok := directives["type"]; ok {
+		return goType, true
+	}
+	goType, ok := p.ColumnTypes[strings.ToLower(column)]
+	return goType, ok
+}
+
+// WriteTypeMap 输出合并后的最终类型映射
+func (p *SQLParser) WriteTypeMap(w io.Writer) error {
+	sqlTypes := make([]string, 0, len(p.TypeMappings))
+	for sqlType := range p.TypeMappings {
+		sqlTypes = append(sqlTypes, sqlType)
+	}
+	for sqlType := range p.NullableTypeMappings {
+		if _, ok := p.TypeMappings[sqlType]; !ok {
+			sqlTypes = append(sqlTypes, sqlType)
+		}
+	}
+	sort.Strings(sqlTypes)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "dialect: %s\n", p.Dialect.Name)
+	fmt.Fprintln(tw, "SQL TYPE\tGO TYPE\tNULLABLE GO TYPE")
+	for _, sqlType := range sqlTypes {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", sqlType, p.TypeMappings[sqlType], p.NullableTypeMappings[sqlType])
+	}
+	if len(p.ColumnTypes) > 0 {
+		columns := make([]string, 0, len(p.ColumnTypes))
+		for column := range p.ColumnTypes {
+			columns = append(columns, column)
+		}
+		sort.Strings(columns)
+		fmt.Fprintln(tw, "\nCOLUMN\tGO TYPE\t")
+		for _, column := range columns {
+			fmt.Fprintf(tw, "%s\t%s\t\n", column, p.ColumnTypes[column])
+		}
+	}
+	return tw.Flush()
+}
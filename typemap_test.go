@@ -0,0 +1,36 @@
+package main
+
+import "testing"
+
+func TestTypeMappingPrecedence(t *testing.T) {
+	dialect, err := LookupDialect("postgres")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewSQLParser("Item", "ItemEntity")
+	p.SetDialect(dialect)
+	p.ApplyTypeMap(&TypeMapConfig{Types: map[string]string{"TEXT": "[]byte", "INTEGER": "int64"}})
+	p.ColumnTypes = map[string]string{"body": "json.RawMessage", "title": "uuid.UUID"}
+	fields := parseFields(t, p, "CREATE TABLE item (\n"+
+		"  id INTEGER NOT NULL,\n"+
+		"  code SMALLINT NOT NULL,\n"+
+		"  summary TEXT NOT NULL,\n"+
+		"  body TEXT NOT NULL,\n"+
+		"  title TEXT NOT NULL\n"+
+		");\n"+
+		"COMMENT ON COLUMN item.title IS '@type:string 标题';")
+	tests := []struct {
+		column, want, layer string
+	}{
+		{"code", "int16", "方言默认映射"},
+		{"id", "int64", "类型映射文件"},
+		{"summary", "[]byte", "类型映射文件"},
+		{"body", "json.RawMessage", "--column-type"},
+		{"title", "string", "@type: 指令"},
+	}
+	for _, tt := range tests {
+		if got := fields[tt.column].FieldType; got != tt.want {
+			t.Errorf("%s 的类型为 %s，期望由%s得到 %s", tt.column, got, tt.layer, tt.want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// tsTypeMappings SQL类型到TypeScript类型的映射
+var tsTypeMappings = map[string]string{
+	"INT":       "number",
+	"SMALLINT":  "number",
+	"TINYINT":   "number",
+	"MEDIUMINT": "number",
+	"BIGINT":    "number",
+	"VARCHAR":   "string",
+	"CHAR":      "string",
+	"TEXT":      "string",
+	"JSON":      "string",
+	"ENUM":      "string",
+	"SET":       "string",
+	"DATETIME":  "Date",
+	"TIMESTAMP": "Date",
+	"DATE":      "Date",
+	"TIME":      "string",
+	"YEAR":      "number",
+	"DOUBLE":    "number",
+	"FLOAT":     "number",
+	"DECIMAL":   "string",
+	"NUMERIC":   "string",
+	"BOOL":      "boolean",
+	"BOOLEAN":   "boolean",
+}
+
+// GenerateTypeScript 根据解析结果生成与结构体对应的TypeScript接口
+func (p *SQLParser) GenerateTypeScript(fileName string) error {
+	var builder strings.Builder
+	tables := p.Tables
+	if len(tables) == 0 {
+		tables = []*SQLParser{p}
+	}
+	for i, table := range tables {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+		table.writeTypeScript(&builder)
+	}
+
+	if err := os.WriteFile(fileName, []byte(builder.String()), 0644); err != nil {
+		return fmt.Errorf("写入TypeScript文件失败: %w", err)
+	}
+	return nil
+}
+
+// writeTypeScript 写入一张表对应的TypeScript接口
+func (p *SQLParser) writeTypeScript(builder *strings.Builder) {
+	builder.WriteString(fmt.Sprintf("/** %s */\n", p.StructName))
+	builder.WriteString(fmt.Sprintf("export interface %s {\n", p.StructName))
+	for _, field := range p.Fields {
+		if field.Ignored {
+			continue
+		}
+		tsType, ok := tsTypeMappings[sqlBaseType(strings.TrimSuffix(field.SQLType, "[]"))]
+		if basicType(field.FieldType) == "bool" {
+			// TINYINT(1) 等映射为 bool 的列
+			tsType, ok = "boolean", true
+		}
+		if !ok {
+			tsType = "unknown"
+		}
+		if field.IsArray {
+			tsType += "[]"
+		}
+		if field.Nullable {
+			tsType += " | null"
+		}
+		if field.Comment != "" {
+			builder.WriteString(fmt.Sprintf("  /** %s */\n", field.Comment))
+		}
+		builder.WriteString(fmt.Sprintf("  %s: %s;\n", field.OriginalField, tsType))
+	}
+	builder.WriteString("}\n")
+}
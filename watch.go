@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/urfave/cli/v2"
+)
+
+// watchDebounce 合并编辑器保存时产生的多次写事件
+const watchDebounce = 200 * time.Millisecond
+
+// watch 监听SQL文件变化并重新生成，收到中断信号时退出
+func watch(c *cli.Context) error {
+	sqlPath, err := filepath.Abs(c.String("sql"))
+	if err != nil {
+		return fmt.Errorf("解析SQL文件路径失败: %w", err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("创建文件监听失败: %w", err)
+	}
+	defer watcher.Close()
+
+	// 类型映射文件变化时同样重新生成
+	watched := map[string]bool{sqlPath: true}
+	if typeMap := c.String("type-map"); typeMap != "" {
+		typeMapPath, err := filepath.Abs(typeMap)
+		if err != nil {
+			return fmt.Errorf("解析类型映射文件路径失败: %w", err)
+		}
+		watched[typeMapPath] = true
+	}
+
+	// 监听所在目录，兼容编辑器以重命名方式保存文件
+	for path := range watched {
+		if err := watcher.Add(filepath.Dir(path)); err != nil {
+			return fmt.Errorf("监听目录失败: %w", err)
+		}
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	fmt.Printf("正在监听: %s\n", sqlPath)
+	timer := time.NewTimer(watchDebounce)
+	timer.Stop()
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if !watched[filepath.Clean(event.Name)] {
+				continue
+			}
+			if event.Has(fsnotify.Write) || event.Has(fsnotify.Create) || event.Has(fsnotify.Rename) {
+				timer.Reset(watchDebounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Fprintf(os.Stderr, "监听错误: %v\n", err)
+		case <-timer.C:
+			start := time.Now()
+			if err := generate(c); err != nil {
+				fmt.Fprintf(os.Stderr, "[%s] 错误: %v\n", start.Format("15:04:05"), err)
+				continue
+			}
+			fmt.Printf("[%s] 重新生成完成 (%s)\n", start.Format("15:04:05"), time.Since(start).Round(time.Millisecond))
+		case <-interrupt:
+			fmt.Println("已停止监听")
+			return nil
+		}
+	}
+}